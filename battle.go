@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+const (
+	battlePower  = 40 // power of every attack, as moves are known by name only
+	statusChance = 10 // percent chance that a hit leaves the wild Pokemon with a status condition
+)
+
+// statusConditions are the conditions a hit can inflict, with how they are announced.
+var statusConditions = []struct{ name, message string }{
+	{"sleep", "fell asleep"},
+	{"freeze", "was frozen solid"},
+	{"paralysis", "is paralyzed"},
+	{"poison", "was poisoned"},
+	{"burn", "was burned"},
+}
+
+// combatant is one side of a battle.
+type combatant struct {
+	name  string
+	level int
+	hp    *int
+	maxHP int
+	stat  func(name string) int
+}
+
+// commandBattle sends the party lead against the wild Pokemon met by the last encounter
+// for one exchange of attacks, the faster one first. Battle again to keep fighting, or
+// throw a ball at the weakened wild Pokemon. The lead's hits may leave the wild Pokemon
+// with a status condition. Knocking the wild Pokemon out gives the lead experience.
+func commandBattle(ctx context.Context, config *Config) error {
+	wild := config.Wild
+	if wild == nil {
+		fmt.Println("There is no wild Pokemon around, use encounter to look for one.")
+		return nil
+	}
+	lead := partyLead(config)
+	if lead == nil {
+		fmt.Println("You have no Pokemon able to battle, add one with party add <name> or heal your party with party heal.")
+		return nil
+	}
+
+	pokemon, err := config.Client.GetPokemon(ctx, wild.Name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("no Pokemon named %s\n", wild.Name)
+		config.Wild = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error fetching Pokemon data: %w", describeAPIError(err))
+	}
+
+	fmt.Printf("Go, %s! (Lv. %d, HP %d/%d)\n", lead.DisplayName(), lead.Level, lead.HP, lead.MaxHP)
+	player := combatant{name: lead.DisplayName(), level: lead.Level, hp: &lead.HP, maxHP: lead.MaxHP, stat: lead.Stat}
+	opponent := combatant{
+		name:  "the wild " + wild.Name,
+		level: wild.Level,
+		hp:    &wild.HP,
+		maxHP: wild.MaxHP,
+		stat:  func(name string) int { return calcStat(pokemon, name, 0, wild.Level) },
+	}
+	turn := func(attacker, defender *combatant) {
+		attack(attacker, defender, config.RNG.IntN)
+		if attacker == &player {
+			inflictStatus(wild, config.RNG.IntN)
+		}
+	}
+	first, second := &player, &opponent
+	if opponent.stat("speed") > player.stat("speed") {
+		first, second = second, first
+	}
+	turn(first, second)
+	if *second.hp > 0 {
+		turn(second, first)
+	}
+
+	if lead.HP == 0 {
+		fmt.Printf("%s fainted! The wild %s is still here.\n", lead.DisplayName(), wild.Name)
+		return saveProgress(config)
+	}
+	if wild.HP > 0 {
+		fmt.Printf("The wild %s is still standing, battle on or try to catch it.\n", wild.Name)
+		return saveProgress(config)
+	}
+	fmt.Printf("The wild %s fainted!\n", wild.Name)
+	config.Wild = nil
+	err = awardExperience(ctx, config, pokemon, wild.Level)
+	return errors.Join(err, saveProgress(config))
+}
+
+func attack(attacker, defender *combatant, intN func(int) int) {
+	dealt := min(damage(attacker, defender, intN), *defender.hp)
+	*defender.hp -= dealt
+	fmt.Printf("%s hits %s for %d damage (HP %d/%d).\n", attacker.name, defender.name, dealt, *defender.hp, defender.maxHP)
+}
+
+// inflictStatus gives a healthy, still standing wild Pokemon a random status condition
+// with statusChance, standing in for the side effects of the moves.
+func inflictStatus(wild *WildPokemon, intN func(int) int) {
+	if wild.Status != "" || wild.HP == 0 || intN(100) >= statusChance {
+		return
+	}
+	condition := statusConditions[intN(len(statusConditions))]
+	wild.Status = condition.name
+	fmt.Printf("The wild %s %s!\n", wild.Name, condition.message)
+}
+
+// damage is the Gen III damage formula without type effectiveness, using the
+// stronger side of the attacker, physical or special, and a random factor of 85% to 100%.
+func damage(attacker, defender *combatant, intN func(int) int) int {
+	attack, defense := attacker.stat("attack"), defender.stat("defense")
+	if special := attacker.stat("special-attack"); special > attack {
+		attack, defense = special, defender.stat("special-defense")
+	}
+	base := (2*attacker.level/5+2)*battlePower*attack/max(defense, 1)/50 + 2
+	return max(base*(85+intN(16))/100, 1)
+}
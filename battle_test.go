@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCommandBattle(t *testing.T) {
+	client := newMockClient(t)
+	config := newTestConfig()
+	config.Client = client
+	pikachu, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bidoof, err := client.GetPokemon(context.Background(), "bidoof")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Caught = []CaughtPokemon{newCaughtPokemon(1, pikachu, 10, "", nil)}
+	config.Party = []int{1}
+	lead := &config.Caught[0]
+
+	run := func(command func(context.Context, *Config) error, args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := command(context.Background(), &config); err != nil {
+				t.Errorf("%v: %v", args, err)
+			}
+		})
+	}
+	// fight battles until one side faints
+	fight := func() string {
+		var output strings.Builder
+		for config.Wild != nil && lead.HP > 0 {
+			output.WriteString(run(commandBattle))
+		}
+		return output.String()
+	}
+	meet := func(level int) {
+		hp := maxHP(bidoof, level)
+		config.Wild = &WildPokemon{Name: "bidoof", Level: level, HP: hp, MaxHP: hp}
+	}
+
+	// Each battle is one exchange, leaving the wild Pokemon weakened but around
+	meet(8)
+	output := run(commandBattle)
+	if config.Wild == nil || config.Wild.HP >= config.Wild.MaxHP || lead.HP == 0 || !strings.Contains(output, "still standing") {
+		t.Fatalf("expected both Pokemon to stand after one exchange, got:\n%s", output)
+	}
+
+	// A level 10 pikachu is no match for a level 30 bidoof
+	meet(30)
+	output = fight()
+	if lead.HP != 0 || !strings.Contains(output, "pikachu fainted! The wild bidoof is still here.") {
+		t.Errorf("expected pikachu to faint, got:\n%s", output)
+	}
+	if config.Wild == nil || config.Wild.HP >= config.Wild.MaxHP {
+		t.Errorf("expected the wild bidoof to stay around weakened, got %+v", config.Wild)
+	}
+	if output := run(commandBattle); !strings.Contains(output, "no Pokemon able to battle") {
+		t.Errorf("expected a fainted party to be unable to battle, got:\n%s", output)
+	}
+
+	run(commandParty, "heal")
+	if lead.HP != lead.MaxHP {
+		t.Errorf("HP %d/%d after healing", lead.HP, lead.MaxHP)
+	}
+
+	// Defeating a level 2 bidoof gives 50*2/7 experience on top of the 1000 of level 10
+	meet(2)
+	output = fight()
+	if config.Wild != nil || !strings.Contains(output, "The wild bidoof fainted!") {
+		t.Errorf("expected pikachu to win, got:\n%s", output)
+	}
+	if lead.Experience != 1000+14 {
+		t.Errorf("experience = %d, expected %d", lead.Experience, 1000+14)
+	}
+
+	// Catching gives experience too, and the new catch starts with the experience of its level
+	meet(45)
+	output = run(commandCatch, "--ball", "master")
+	lead = caughtByID(config.Caught, 1) // the catch grew config.Caught
+	if lead.Experience != 1000+14+321 || lead.Level != 11 || !strings.Contains(output, "pikachu grew to level 11!") {
+		t.Errorf("expected pikachu to reach level 11 with %d experience, got level %d with %d:\n%s", 1000+14+321, lead.Level, lead.Experience, output)
+	}
+	if caught := config.Caught[1]; caught.Pokemon.Name != "bidoof" || caught.Experience != 45*45*45 {
+		t.Errorf("caught %s with %d experience, expected bidoof with %d", caught.Pokemon.Name, caught.Experience, 45*45*45)
+	}
+}
+
+func TestInflictStatus(t *testing.T) {
+	rolls := func(values ...int) func(int) int {
+		return func(n int) int {
+			value := values[0]
+			values = values[1:]
+			return value
+		}
+	}
+
+	wild := WildPokemon{Name: "bidoof", HP: 10}
+	captureOutput(t, func() { inflictStatus(&wild, rolls(statusChance)) })
+	if wild.Status != "" {
+		t.Errorf("a roll of %d inflicted %q", statusChance, wild.Status)
+	}
+	output := captureOutput(t, func() { inflictStatus(&wild, rolls(statusChance-1, 2)) })
+	if wild.Status != "paralysis" || !strings.Contains(output, "The wild bidoof is paralyzed!") {
+		t.Errorf("expected paralysis, got %q:\n%s", wild.Status, output)
+	}
+	// A Pokemon keeps its first status condition
+	captureOutput(t, func() { inflictStatus(&wild, rolls(0, 0)) })
+	if wild.Status != "paralysis" {
+		t.Errorf("status changed to %q", wild.Status)
+	}
+
+	fainted := WildPokemon{Name: "bidoof"}
+	captureOutput(t, func() { inflictStatus(&fainted, rolls(0, 0)) })
+	if fainted.Status != "" {
+		t.Errorf("a fainted Pokemon got %q", fainted.Status)
+	}
+}
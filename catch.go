@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// ballType is a kind of Poke Ball, named like the PokeAPI item.
+type ballType struct {
+	item     string
+	name     string
+	modifier float64 // catch rate multiplier
+}
+
+var ballTypes = []ballType{
+	{item: "poke-ball", name: "Poke Ball", modifier: 1},
+	{item: "great-ball", name: "Great Ball", modifier: 1.5},
+	{item: "ultra-ball", name: "Ultra Ball", modifier: 2},
+	{item: "master-ball", name: "Master Ball", modifier: 255}, // high enough to never fail
+}
+
+// startingBalls is the bag of a new Pokedex.
+func startingBalls() map[string]int {
+	return map[string]int{"poke-ball": 20, "great-ball": 10, "ultra-ball": 5, "master-ball": 1}
+}
+
+// findBall accepts "great", "great-ball" or "greatball".
+func findBall(name string) (ballType, bool) {
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "ball"), "-")
+	for _, ball := range ballTypes {
+		if ball.item == name+"-ball" {
+			return ball, true
+		}
+	}
+	return ballType{}, false
+}
+
+// commandCatch throws a ball at the wild Pokemon met by the last encounter:
+// "catch [name] [--ball <type>]". Naming the Pokemon is optional, but only that one can be caught.
+func commandCatch(ctx context.Context, config *Config) error {
+	wild := config.Wild
+	if wild == nil {
+		fmt.Println("There is no wild Pokemon around, use encounter to look for one.")
+		return nil
+	}
+	if config.PokemonName != "" && config.PokemonName != wild.Name {
+		fmt.Printf("You have not encountered %s, the wild Pokemon here is %s.\n", config.PokemonName, wild.Name)
+		return nil
+	}
+
+	ball := ballTypes[0]
+	args := config.Args
+	if len(args) > 0 && !strings.HasPrefix(args[0], "--") {
+		args = args[1:] // the Pokemon name
+	}
+	if len(args) > 0 {
+		flag, value, hasValue := strings.Cut(args[0], "=")
+		if !hasValue && len(args) > 1 {
+			value = args[1]
+		}
+		var ok bool
+		if ball, ok = findBall(value); flag != "--ball" || !ok {
+			fmt.Println("Usage: catch [name] [--ball poke|great|ultra|master]")
+			return nil
+		}
+	}
+	if config.Balls[ball.item] <= 0 {
+		fmt.Printf("You have no %ss left.\n", ball.name)
+		return nil
+	}
+
+	pokemon, err := config.Client.GetPokemon(ctx, wild.Name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("no Pokemon named %s\n", wild.Name)
+		config.Wild = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error fetching Pokemon data: %w", describeAPIError(err))
+	}
+	if !inVersion(pokemon, config.Version) {
+		fmt.Printf("%s cannot be found in Pokemon %s.\n", pokemon.Name, config.Version)
+		return nil
+	}
+	species, err := config.Client.GetPokemonSpecies(ctx, pokemon.Species.Name)
+	if err != nil {
+		return fmt.Errorf("error fetching species data: %w", describeAPIError(err))
+	}
+	growth, err := config.Client.GetGrowthRate(ctx, species.GrowthRate.Name)
+	if err != nil {
+		return fmt.Errorf("error fetching growth rate: %w", describeAPIError(err))
+	}
+
+	config.Balls[ball.item]--
+	fmt.Printf("Throwing a %s at %s...\n", ball.name, pokemon.Name)
+	rate := modifiedCatchRate(species.CaptureRate, wild.HP, wild.MaxHP, ball.modifier, statusBonus(wild.Status))
+	shakes, caught := throwBall(rate, config.RNG.IntN)
+	for range min(shakes, 3) {
+		fmt.Println("The ball shakes...")
+	}
+
+	if !caught {
+		fmt.Printf("%s broke free! %ss left: %d\n", pokemon.Name, ball.name, config.Balls[ball.item])
+		// The bag changed anyway
+		return saveProgress(config)
+	}
+
+	fmt.Printf("Caught %s!\n", pokemon.Name)
+	fmt.Println("You may now inspect it with the inspect command.")
+	record := newCaughtPokemon(nextCaughtID(config.Caught), pokemon, wild.Level, config.VersionGroup, config.RNG.IntN)
+	record.CaughtAt = time.Now()
+	record.Area = wild.Area
+	record.Ball = ball.item
+	record.Experience = experienceAt(growth, record.Level)
+	record.Friendship = species.BaseHappiness
+	config.Wild = nil
+	// As in the later games, the party lead gains experience for the catch
+	err = awardExperience(ctx, config, pokemon, wild.Level)
+	config.Caught = append(config.Caught, record)
+	return errors.Join(err, saveProgress(config))
+}
+
+// commandBag lists the balls and evolution items left.
+func commandBag(ctx context.Context, config *Config) error {
+	fmt.Println("Your bag:")
+	for _, ball := range ballTypes {
+		fmt.Printf("- %s: %d\n", ball.name, config.Balls[ball.item])
+	}
+	items := slices.Sorted(maps.Keys(config.Items))
+	for _, item := range items {
+		if config.Items[item] > 0 {
+			fmt.Printf("- %s: %d\n", item, config.Items[item])
+		}
+	}
+	return nil
+}
+
+// statusBonus is the catch rate multiplier of a status condition, as in Gen III and IV.
+func statusBonus(status string) float64 {
+	switch status {
+	case "sleep", "freeze":
+		return 2
+	case "paralysis", "poison", "burn":
+		return 1.5
+	}
+	return 1
+}
+
+// modifiedCatchRate is the "a" value of the Gen III/IV catch formula:
+// the species capture rate, raised as HP drops and by the ball and status bonuses.
+// An unknown max HP counts as full health.
+func modifiedCatchRate(captureRate, hp, maxHP int, ball, status float64) float64 {
+	if maxHP <= 0 {
+		hp, maxHP = 1, 1
+	}
+	return float64(3*maxHP-2*hp) * float64(captureRate) * ball / float64(3*maxHP) * status
+}
+
+// throwBall runs the four shake checks of the Gen III/IV formula for a modified catch rate.
+// A rate of 255 or more always catches. intN returns a random number in [0, n).
+func throwBall(rate float64, intN func(n int) int) (shakes int, caught bool) {
+	if rate >= 255 {
+		return 4, true
+	}
+	threshold := 1048560 / math.Sqrt(math.Sqrt(16711680/rate))
+	for shakes = 0; shakes < 4; shakes++ {
+		if float64(intN(65536)) >= threshold {
+			return shakes, false
+		}
+	}
+	return 4, true
+}
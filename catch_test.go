@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestModifiedCatchRate(t *testing.T) {
+	cases := []struct {
+		name        string
+		captureRate int
+		hp, maxHP   int
+		ball        float64
+		status      string
+		expected    float64
+	}{
+		{name: "full hp", captureRate: 45, hp: 100, maxHP: 100, ball: 1, expected: 15},
+		{name: "half hp", captureRate: 45, hp: 50, maxHP: 100, ball: 1, expected: 30},
+		{name: "one hp", captureRate: 45, hp: 1, maxHP: 100, ball: 1, expected: 44.7},
+		{name: "ultra ball", captureRate: 45, hp: 50, maxHP: 100, ball: 2, expected: 60},
+		{name: "asleep", captureRate: 45, hp: 50, maxHP: 100, ball: 2, status: "sleep", expected: 120},
+		{name: "paralyzed", captureRate: 190, hp: 100, maxHP: 100, ball: 1.5, status: "paralysis", expected: 142.5},
+		{name: "unknown hp", captureRate: 255, ball: 1, expected: 85},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rate := modifiedCatchRate(c.captureRate, c.hp, c.maxHP, c.ball, statusBonus(c.status))
+			if math.Abs(rate-c.expected) > 1e-9 {
+				t.Errorf("modifiedCatchRate() = %v, expected %v", rate, c.expected)
+			}
+		})
+	}
+}
+
+func TestThrowBall(t *testing.T) {
+	never := func(n int) int {
+		t.Fatalf("a sure catch must not roll")
+		return 0
+	}
+	if shakes, caught := throwBall(255, never); !caught || shakes != 4 {
+		t.Errorf("throwBall(255) = %d, %v", shakes, caught)
+	}
+
+	// A rate of 63.3 (pikachu with a Poke Ball) gives a shake threshold of about 46253
+	rate := 190.0 / 3
+	rolls := func(values ...int) func(int) int {
+		return func(n int) int {
+			if n != 65536 {
+				t.Fatalf("expected shake checks out of 65536, got %d", n)
+			}
+			value := values[0]
+			values = values[1:]
+			return value
+		}
+	}
+	if shakes, caught := throwBall(rate, rolls(46000, 0, 100, 46250)); !caught || shakes != 4 {
+		t.Errorf("expected four passed checks to catch, got %d shakes, caught %v", shakes, caught)
+	}
+	if shakes, caught := throwBall(rate, rolls(0, 1, 46300)); caught || shakes != 2 {
+		t.Errorf("expected the third check to fail after two shakes, got %d shakes, caught %v", shakes, caught)
+	}
+}
+
+func TestCommandCatchUsesBalls(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	config.Wild = &WildPokemon{Name: "magikarp", Level: 10, HP: 30, MaxHP: 30}
+
+	run := func(args ...string) string {
+		config.Args = args
+		config.PokemonName = ""
+		if len(args) > 0 && !strings.HasPrefix(args[0], "--") {
+			config.PokemonName = args[0]
+		}
+		return captureOutput(t, func() {
+			if err := commandCatch(context.Background(), &config); err != nil {
+				t.Errorf("commandCatch(%v): %v", args, err)
+			}
+		})
+	}
+
+	if output := run("--ball", "premier"); !strings.Contains(output, "Usage: catch") {
+		t.Errorf("expected an unknown ball to be reported, got:\n%s", output)
+	}
+	config.Balls["ultra-ball"] = 0
+	if output := run("--ball=ultra"); !strings.Contains(output, "no Ultra Balls left") {
+		t.Errorf("expected an empty ball slot to be reported, got:\n%s", output)
+	}
+
+	if output := run("magikarp", "--ball", "master-ball"); !strings.Contains(output, "Throwing a Master Ball at magikarp") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+	if len(caughtNamed(config.Caught, "magikarp")) != 1 || config.Balls["master-ball"] != 0 {
+		t.Errorf("expected the Master Ball to catch magikarp and be used up, balls: %v", config.Balls)
+	}
+}
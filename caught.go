@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// maxIV is the highest individual value of a stat, as in the games since Gen III.
+const maxIV = 31
+
+// CaughtPokemon is one Pokemon the player caught. Catching the same species twice
+// gives two CaughtPokemon, told apart by their ID.
+type CaughtPokemon struct {
+	ID          int             `json:"id"` // unique among the caught Pokemon, in catch order
+	Pokemon     pokeapi.Pokemon `json:"pokemon"`
+	CaughtAt    time.Time       `json:"caught_at"`
+	Area        string          `json:"area,omitempty"` // area it was caught in, if known
+	Level       int             `json:"level"`
+	Experience  int             `json:"experience,omitempty"` // total gained, 0 if never recorded
+	Friendship  int             `json:"friendship"`
+	Nickname    string          `json:"nickname,omitempty"`
+	IVs         map[string]int  `json:"ivs,omitempty"`  // individual values 0-31, keyed by stat name like "special-attack"
+	Ball        string          `json:"ball,omitempty"` // item name of the ball it was caught with, if known
+	HP          int             `json:"hp"`
+	MaxHP       int             `json:"max_hp"`
+	Moves       []string        `json:"moves,omitempty"`        // up to four
+	EvolvedFrom []string        `json:"evolved_from,omitempty"` // species it was before evolving, oldest first
+}
+
+// DisplayName is the nickname, or the Pokemon name without one.
+func (c CaughtPokemon) DisplayName() string {
+	if c.Nickname != "" {
+		return c.Nickname
+	}
+	return c.Pokemon.Name
+}
+
+// newCaughtPokemon records pokemon as caught at level, with random IVs drawn from intN.
+// It starts at full HP, knowing the last four moves it learned by leveling up.
+// Pokemon caught before levels were recorded start at level 5. Experience and friendship
+// are left for the caller to set from the species.
+func newCaughtPokemon(id int, pokemon pokeapi.Pokemon, level int, versionGroup string, intN func(int) int) CaughtPokemon {
+	if level <= 0 {
+		level = 5
+	}
+	var ivs map[string]int
+	if intN != nil {
+		ivs = make(map[string]int, len(pokemon.Stats))
+		for _, stat := range pokemon.Stats {
+			ivs[stat.Stat.Name] = intN(maxIV + 1)
+		}
+	}
+	caught := CaughtPokemon{
+		ID:      id,
+		Pokemon: pokemon,
+		Level:   level,
+		IVs:     ivs,
+		Moves:   knownMoves(pokemon, level, versionGroup),
+	}
+	caught.MaxHP = caught.Stat("hp")
+	caught.HP = caught.MaxHP
+	return caught
+}
+
+// knownMoves returns the last four moves pokemon learned by leveling up to level.
+func knownMoves(pokemon pokeapi.Pokemon, level int, versionGroup string) []string {
+	var moves []string
+	for _, move := range levelUpMoves(pokemon, movesVersionGroup(pokemon, versionGroup)) {
+		if move.level <= level && !slices.Contains(moves, move.name) {
+			moves = append(moves, move.name)
+		}
+	}
+	return moves[max(len(moves)-4, 0):]
+}
+
+// movesVersionGroup returns versionGroup, or without one the version group
+// in which the Pokemon learns the most moves by leveling up.
+func movesVersionGroup(pokemon pokeapi.Pokemon, versionGroup string) string {
+	if versionGroup != "" {
+		return versionGroup
+	}
+	counts := make(map[string]int)
+	for _, move := range pokemon.Moves {
+		for _, details := range move.VersionGroupDetails {
+			if details.MoveLearnMethod.Name == "level-up" {
+				counts[details.VersionGroup.Name]++
+			}
+		}
+	}
+	best := ""
+	for group, count := range counts {
+		if count > counts[best] || (count == counts[best] && group < best) {
+			best = group
+		}
+	}
+	return best
+}
+
+// nextCaughtID returns the ID of the next Pokemon caught.
+func nextCaughtID(caught []CaughtPokemon) int {
+	id := 0
+	for _, c := range caught {
+		id = max(id, c.ID)
+	}
+	return id + 1
+}
+
+// caughtByID returns the caught Pokemon with id, nil if there is none.
+func caughtByID(caught []CaughtPokemon, id int) *CaughtPokemon {
+	for i := range caught {
+		if caught[i].ID == id {
+			return &caught[i]
+		}
+	}
+	return nil
+}
+
+// caughtNamed returns the caught Pokemon of the species name, or the one nicknamed name.
+func caughtNamed(caught []CaughtPokemon, name string) []*CaughtPokemon {
+	var found []*CaughtPokemon
+	for i := range caught {
+		if caught[i].Pokemon.Name == name || caught[i].Nickname == name {
+			found = append(found, &caught[i])
+		}
+	}
+	return found
+}
+
+// caughtSpecies counts the different species caught.
+func caughtSpecies(caught []CaughtPokemon) map[string]int {
+	species := make(map[string]int)
+	for _, c := range caught {
+		species[c.Pokemon.Name]++
+	}
+	return species
+}
+
+// printCaught describes a caught Pokemon on one line, followed by its stats, friendship,
+// former species and IVs.
+func printCaught(c CaughtPokemon) {
+	name := c.Pokemon.Name
+	if c.Nickname != "" {
+		name = fmt.Sprintf("%s (%s)", c.Nickname, c.Pokemon.Name)
+	}
+	fmt.Printf("  #%d %s Lv. %d", c.ID, name, c.Level)
+	if c.Area != "" {
+		fmt.Printf(", caught in %s", c.Area)
+	}
+	if ball, ok := findBall(c.Ball); ok {
+		fmt.Printf(" with a %s", ball.name)
+	}
+	if !c.CaughtAt.IsZero() {
+		fmt.Printf(" on %s", c.CaughtAt.Format(time.DateOnly))
+	}
+	fmt.Println()
+	if c.Experience > 0 {
+		fmt.Printf("    Exp.: %d\n", c.Experience)
+	}
+	stats := make([]string, 0, len(c.Pokemon.Stats))
+	for _, stat := range c.Pokemon.Stats {
+		stats = append(stats, fmt.Sprintf("%s %d", stat.Stat.Name, c.Stat(stat.Stat.Name)))
+	}
+	fmt.Printf("    Stats: %s\n", strings.Join(stats, ", "))
+	fmt.Printf("    Friendship: %d\n", c.Friendship)
+	if len(c.EvolvedFrom) > 0 {
+		fmt.Printf("    Evolved from: %s\n", strings.Join(c.EvolvedFrom, ", "))
+	}
+	if len(c.IVs) == 0 {
+		return
+	}
+	ivs := make([]string, 0, len(c.Pokemon.Stats))
+	for _, stat := range c.Pokemon.Stats {
+		ivs = append(ivs, fmt.Sprintf("%s %d", stat.Stat.Name, c.IVs[stat.Stat.Name]))
+	}
+	fmt.Printf("    IVs: %s\n", strings.Join(ivs, ", "))
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCaughtPokemon(t *testing.T) {
+	pikachu, err := newMockClient(t).GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	draws := 0
+	caught := newCaughtPokemon(3, pikachu, 30, "", func(n int) int {
+		if n != maxIV+1 {
+			t.Errorf("IVs drawn below %d, expected below %d", n, maxIV+1)
+		}
+		draws++
+		return draws
+	})
+	// At level 30 pikachu knows the last four moves it learned, base HP 35 gives 2*35*30/100+30+10
+	expectedMoves := []string{"tail-whip", "thunder-wave", "quick-attack", "thunderbolt"}
+	if caught.ID != 3 || caught.Level != 30 || caught.HP != 61 || caught.MaxHP != 61 || !slices.Equal(caught.Moves, expectedMoves) {
+		t.Errorf("unexpected caught Pokemon %+v", caught)
+	}
+	if len(caught.IVs) != 6 || caught.IVs["hp"] != 1 || caught.IVs["speed"] != 6 {
+		t.Errorf("IVs = %v, expected one draw per stat in stat order", caught.IVs)
+	}
+
+	if unknown := newCaughtPokemon(4, pikachu, 0, "", nil); unknown.Level != 5 || unknown.IVs != nil {
+		t.Errorf("expected an unknown level to default to 5 without IVs, got %+v", unknown)
+	}
+}
+
+func TestCommandInspectCaught(t *testing.T) {
+	pikachu, err := newMockClient(t).GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := newTestConfig()
+	first := newCaughtPokemon(1, pikachu, 5, "", func(int) int { return 31 })
+	first.CaughtAt = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	first.Area = "viridian-forest-area"
+	first.Ball = "great-ball"
+	second := newCaughtPokemon(2, pikachu, 9, "", nil)
+	second.Nickname = "sparky"
+	config.Caught = []CaughtPokemon{first, second}
+
+	inspect := func(name string) string {
+		config.PokemonName = name
+		return captureOutput(t, func() {
+			if err := commandInspect(context.Background(), &config); err != nil {
+				t.Errorf("commandInspect: %v", err)
+			}
+		})
+	}
+
+	output := inspect("pikachu")
+	for _, expected := range []string{
+		"Name: pikachu",
+		"Caught (2):",
+		"#1 pikachu Lv. 5, caught in viridian-forest-area with a Great Ball on 2024-05-01",
+		"IVs: hp 31, attack 31,",
+		"#2 sparky (pikachu) Lv. 9\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected inspect pikachu to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if output := inspect("sparky"); !strings.Contains(output, "Caught (1):\n  #2 sparky") {
+		t.Errorf("expected inspect to find a Pokemon by nickname, got:\n%s", output)
+	}
+}
@@ -1,34 +1,45 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"math/rand/v2"
-	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/AGX18/pokedex/internal/pokecache"
+	"github.com/AGX18/pokedex/internal/pokeapi"
 )
 
-func commandInspect(config *Config) error {
+func commandInspect(ctx context.Context, config *Config) error {
 	if config.PokemonName == "" {
 		fmt.Println("Please provide a Pokemon name to inspect.")
 		return nil
 	}
 
-	if pokemon, found := config.Pokedex[config.PokemonName]; found {
-		printInfo(pokemon)
-
-	} else {
+	caught := caughtNamed(config.Caught, config.PokemonName)
+	if len(caught) == 0 {
 		fmt.Println("you have not caught that pokemon")
+		return nil
 	}
 
+	printInfo(caught[0].Pokemon, config.VersionGroup)
+	fmt.Printf("Caught (%d):\n", len(caught))
+	for _, c := range caught {
+		printCaught(*c)
+	}
 	return nil
 }
 
-func printInfo(pokemon Pokemon) {
+// printInfo describes a Pokemon, with its sprite and level-up moves as in versionGroup when one is set.
+func printInfo(pokemon pokeapi.Pokemon, versionGroup string) {
 	fmt.Printf("Name: %s\n", pokemon.Name)
 	fmt.Printf("Height: %d\n", pokemon.Height)
 	fmt.Printf("Weight: %d\n", pokemon.Weight)
+	if sprite := spriteURL(pokemon, versionGroup); sprite != "" {
+		fmt.Printf("Sprite: %s\n", sprite)
+	}
 	fmt.Printf("Stats:\n")
 	for _, stat := range pokemon.Stats {
 		fmt.Printf("  - %s: %d\n", stat.Stat.Name, stat.BaseStat)
@@ -38,23 +49,54 @@ func printInfo(pokemon Pokemon) {
 	for _, t := range pokemon.Types {
 		fmt.Printf("- %s\n", t.Type.Name)
 	}
+
+	if versionGroup == "" {
+		return
+	}
+	moves := levelUpMoves(pokemon, versionGroup)
+	if len(moves) == 0 {
+		fmt.Printf("Learns no moves by leveling up in %s.\n", versionGroup)
+		return
+	}
+	fmt.Printf("Moves (%s):\n", versionGroup)
+	for _, move := range moves {
+		fmt.Printf("  - level %d: %s\n", move.level, move.name)
+	}
 }
 
-func commandPokedex(config *Config) error {
-	if len(config.Pokedex) == 0 {
+// commandPokedex lists every Pokemon seen in the wild, marking the caught ones.
+func commandPokedex(ctx context.Context, config *Config) error {
+	species := caughtSpecies(config.Caught)
+	names := make([]string, 0, len(config.Seen)+len(species))
+	for name := range config.Seen {
+		names = append(names, name)
+	}
+	for name := range species {
+		if !config.Seen[name] {
+			names = append(names, name) // caught before sightings were recorded
+		}
+	}
+	if len(names) == 0 {
 		fmt.Println("Your Pokedex is empty. Catch some Pokemon first!")
 		return nil
 	}
+	slices.Sort(names)
 
-	fmt.Println("Your Pokedex:")
-	for name := range config.Pokedex {
-		fmt.Printf("- %s\n", name)
+	fmt.Printf("Your Pokedex (seen %d, caught %d):\n", len(names), len(species))
+	for _, name := range names {
+		switch count := species[name]; count {
+		case 0:
+			fmt.Printf("- %s\n", name)
+		case 1:
+			fmt.Printf("- %s (caught)\n", name)
+		default:
+			fmt.Printf("- %s (caught %d)\n", name, count)
+		}
 	}
-
 	return nil
 }
 
-func commandHelp(config *Config) error {
+func commandHelp(ctx context.Context, config *Config) error {
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Usage: pokedex [command]")
 	for cmd, command := range supportedCommands {
@@ -63,60 +105,92 @@ func commandHelp(config *Config) error {
 	return nil
 }
 
-func commandMap(config *Config) error {
-	if config.NextURL == "" {
-		fmt.Println("No more location areas available.")
+// commandMap shows the next page of location areas.
+// "map --limit <n>" changes the page size, "map page <n>" jumps to a page
+// and "map goto <offset>" to the page starting at that offset.
+func commandMap(ctx context.Context, config *Config) error {
+	offset, limit, ok := parseMapArgs(config)
+	if !ok {
 		return nil
 	}
-	// Check if the URL is cached
-	var response LocationAreaListResponse
-	err := GetWithCache(config.NextURL, cache, &response)
-	if err != nil {
-		return fmt.Errorf("error fetching location areas: %w", err)
-	}
-
-	// Update config URLs
-	if response.Previous != nil {
-		config.PrevURL = *response.Previous
-	} else {
-		config.PrevURL = ""
-	}
-	if response.Next != nil {
-		config.NextURL = *response.Next
-	} else {
-		config.NextURL = ""
+	if config.AreaCount > 0 && offset >= config.AreaCount {
+		fmt.Printf("No more location areas available, there are %d.\n", config.AreaCount)
+		return nil
 	}
-	displayLocationAreas(response.Results)
-	return nil
+	return showLocationAreas(ctx, config, offset, limit)
 }
 
-func commandMapBack(config *Config) error {
-	if config.PrevURL == "" {
+// commandMapBack shows the page before the one on screen, wherever map jumped to.
+func commandMapBack(ctx context.Context, config *Config) error {
+	current := config.Offset - config.Limit
+	if current <= 0 {
 		fmt.Println("No previous location areas available.")
 		return nil
 	}
-	var response LocationAreaListResponse
-	err := GetWithCache(config.PrevURL, cache, &response)
-	if err != nil {
-		return fmt.Errorf("error fetching previous location areas: %w", err)
-	}
+	return showLocationAreas(ctx, config, max(current-config.Limit, 0), config.Limit)
+}
+
+// parseMapArgs returns the offset and page size requested by the map arguments.
+// Without arguments map continues after the current page.
+func parseMapArgs(config *Config) (offset, limit int, ok bool) {
+	offset, limit = config.Offset, config.Limit
+	page := 0
+	args := config.Args
+	for i := 0; i < len(args); i++ {
+		arg, value, hasValue := strings.Cut(args[i], "=")
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+		n, err := strconv.Atoi(value)
+		switch {
+		case arg != "--limit" && arg != "page" && arg != "goto":
+			fmt.Printf("Unknown map argument %q.\n", args[i])
+			fmt.Println("Usage: map [--limit <n>] [page <n> | goto <offset>]")
+			return 0, 0, false
+		case err != nil || n < 0 || (n == 0 && arg != "goto"):
+			fmt.Printf("%s needs a positive number.\n", arg)
+			return 0, 0, false
+		}
+		if !hasValue {
+			i++
+		}
 
-	// Update config URLs
-	if response.Previous != nil {
-		config.PrevURL = *response.Previous
-	} else {
-		config.PrevURL = ""
+		switch arg {
+		case "--limit":
+			limit = n
+		case "page":
+			page = n
+		case "goto":
+			offset = n
+		}
 	}
-	if response.Next != nil {
-		config.NextURL = *response.Next
-	} else {
-		config.NextURL = ""
+	// The page number depends on the page size, wherever --limit was given
+	if page > 0 {
+		offset = (page - 1) * limit
 	}
+	return offset, limit, true
+}
+
+// showLocationAreas fetches and prints a page of location areas.
+// The position only moves once the page is shown, so a failed request leaves mapb working.
+func showLocationAreas(ctx context.Context, config *Config, offset, limit int) error {
+	response, err := config.Client.ListLocationAreas(ctx, offset, limit)
+	if err != nil {
+		return fmt.Errorf("error fetching location areas: %w", describeAPIError(err))
+	}
+	config.Offset = offset + limit
+	config.Limit = limit
+	config.AreaCount = response.Count
+
 	displayLocationAreas(response.Results)
+	if len(response.Results) > 0 {
+		pages := max((response.Count+limit-1)/limit, 1)
+		fmt.Printf("Page %d of %d\n", offset/limit+1, pages)
+	}
 	return nil
 }
 
-func displayLocationAreas(locationAreas []LocationAreaSummary) {
+func displayLocationAreas(locationAreas []pokeapi.LocationAreaSummary) {
 	if len(locationAreas) == 0 {
 		fmt.Println("No more location areas found.")
 		return
@@ -126,97 +200,67 @@ func displayLocationAreas(locationAreas []LocationAreaSummary) {
 	}
 }
 
-func commandExplore(config *Config) error {
-	if config.AreaName == "" && config.AreaID == 0 {
-		fmt.Println("Please provide an area name or ID to explore.")
+func commandCache(ctx context.Context, config *Config) error {
+	cache := config.Client.Cache()
+	if cache == nil {
+		fmt.Println("Caching is disabled.")
 		return nil
 	}
-
-	var url string
-	if config.AreaName != "" {
-		url = fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%s", config.AreaName)
-		fmt.Printf("Exploring %s...\n", config.AreaName)
-	} else {
-		url = fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%d", config.AreaID)
-	}
-	// Check if the URL is cached
-	var area LocationArea
-	err := GetWithCache(url, cache, &area)
-	if err != nil {
-		return fmt.Errorf("error fetching area data: %w", err)
-	}
-
-	// Cache the response
-
-	fmt.Printf("Found Pokemon:\n")
-	for _, encounter := range area.PokemonEncounters {
-		fmt.Printf("- %s\n", encounter.Pokemon.Name)
-	}
-	return nil
-}
-
-func commandCatch(config *Config) error {
-	if config.PokemonName == "" {
-		fmt.Println("Please provide a Pokemon name to catch.")
+	if len(config.Args) == 0 {
+		fmt.Println("Usage: cache stats | cache list [prefix] | cache clear [prefix]")
 		return nil
 	}
-	fmt.Printf("Throwing a Pokeball at %s...\n", config.PokemonName)
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%s", config.PokemonName)
-	// Check if the URL is cached
-	var pokemon Pokemon
-	err := GetWithCache(url, cache, &pokemon)
-	if err != nil {
-		return err
-	}
-
-	CatchProbability := catchProbability(pokemon.BaseExperience)
 
-	if CatchProbability >= 5 {
-		fmt.Printf("Caught %s!\n", pokemon.Name)
-		fmt.Println("You may now inspect it with the inspect command.")
-		config.Pokedex[pokemon.Name] = pokemon
-	} else {
-		fmt.Printf("%s escaped!\n", pokemon.Name)
-	}
-
-	return nil
-}
-
-func catchProbability(max int) int {
-	if max <= 1 {
-		return 0
-	}
-	factor := 1.0 / (1 + float64(max)/10.0) // decay function
-	return int(float64(max) * rand.Float64() * factor)
-}
-
-func GetWithCache[T any](url string, cache *pokecache.Cache, target *T) error {
-	cachedData, found := cache.Get(url)
-	if found {
-		err := json.Unmarshal(cachedData, target)
-		if err != nil {
-			return fmt.Errorf("error decoding cached JSON: %w", err)
+	prefix := ""
+	if len(config.Args) > 1 {
+		prefix = config.Args[1]
+		// Let users type "pokemon/" instead of the whole URL
+		if !strings.HasPrefix(prefix, "http") {
+			prefix = config.Client.BaseURL() + "/" + prefix
 		}
-		return nil
 	}
 
-	// Fetch from HTTP if not in cache
-	res, err := http.Get(url)
-	if err != nil {
-		return fmt.Errorf("error fetching data from %s: %w", url, err)
-	}
-	defer res.Body.Close()
-
-	err = json.NewDecoder(res.Body).Decode(target)
-	if err != nil {
-		return fmt.Errorf("error decoding JSON: %w", err)
+	switch config.Args[0] {
+	case "stats":
+		stats := cache.Stats()
+		lookups := stats.Hits + stats.Misses
+		hitRate := 0.0
+		if lookups > 0 {
+			hitRate = 100 * float64(stats.Hits) / float64(lookups)
+		}
+		fmt.Printf("Hits: %d (%d from disk)\n", stats.Hits, stats.DiskHits)
+		fmt.Printf("Misses: %d\n", stats.Misses)
+		fmt.Printf("Hit rate: %.1f%%\n", hitRate)
+		fmt.Printf("Evictions: %d\n", stats.Evictions)
+		fmt.Printf("Expirations: %d\n", stats.Expirations)
+		fmt.Printf("Entries: %d\n", stats.Entries)
+		fmt.Printf("Bytes: %d\n", stats.Bytes)
+	case "list":
+		entries := cache.Entries(prefix)
+		if len(entries) == 0 {
+			fmt.Println("The cache is empty.")
+			return nil
+		}
+		for _, entry := range entries {
+			fmt.Printf("- %s (%d bytes, expires in %s)\n", entry.Key, entry.Size, time.Until(entry.ExpiresAt).Round(time.Second))
+		}
+	case "clear":
+		removed := cache.ClearPrefix(prefix)
+		fmt.Printf("Removed %d cached entries.\n", removed)
+	default:
+		fmt.Printf("Unknown cache command %q.\n", config.Args[0])
 	}
+	return nil
+}
 
-	data, err := json.Marshal(target)
-	if err != nil {
-		return fmt.Errorf("error encoding JSON for cache: %w", err)
+// describeAPIError adds a hint for the API errors a user can do something about.
+// The original error stays wrapped so callers can still match it.
+func describeAPIError(err error) error {
+	switch {
+	case errors.Is(err, pokeapi.ErrRateLimited):
+		return fmt.Errorf("PokeAPI is rate limiting requests, try again in a moment: %w", err)
+	case errors.Is(err, pokeapi.ErrServerError):
+		return fmt.Errorf("PokeAPI is having trouble, try again later: %w", err)
 	}
-
-	cache.Add(url, data)
-	return nil
+	return err
 }
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// WildPokemon is the Pokemon met by the last encounter, the only one that can be caught.
+type WildPokemon struct {
+	Name   string
+	Level  int
+	Area   string
+	Method string // encounter method, e.g. "walk" or "old-rod"
+	HP     int
+	MaxHP  int
+	Status string // status condition, e.g. "sleep", empty when healthy
+}
+
+// commandEncounter looks for a wild Pokemon in the area explored last,
+// picked by the encounter chances of the area in the session's game version, or
+// without one in the first version the area lists.
+// "encounter --method <method>" fishes or surfs instead of walking through the grass.
+func commandEncounter(ctx context.Context, config *Config) error {
+	if config.CurrentArea == "" {
+		fmt.Println("Explore a location area first to look for wild Pokemon there.")
+		return nil
+	}
+	method := ""
+	if len(config.Args) == 2 && config.Args[0] == "--method" {
+		method = config.Args[1]
+	} else if len(config.Args) > 0 {
+		fmt.Println("Usage: encounter [--method <method>]")
+		return nil
+	}
+
+	area, err := config.Client.GetLocationArea(ctx, config.CurrentArea)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("no location area named %s\n", config.CurrentArea)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error fetching area data: %w", describeAPIError(err))
+	}
+
+	rows := encounterRows(area, exploreOptions{version: encounterVersion(area, config.Version, method)})
+	if method == "" {
+		method = defaultEncounterMethod(rows)
+	}
+	rows = slices.DeleteFunc(rows, func(row encounterRow) bool { return row.method != method })
+	if len(rows) == 0 {
+		fmt.Printf("No wild Pokemon can be found in %s", area.Name)
+		if method != "" {
+			fmt.Printf(" with %s", method)
+		}
+		fmt.Println(".")
+		return nil
+	}
+
+	row, level := pickEncounter(rows, config.RNG.IntN)
+	pokemon, err := config.Client.GetPokemon(ctx, row.pokemon)
+	if err != nil {
+		return fmt.Errorf("error fetching Pokemon data: %w", describeAPIError(err))
+	}
+	hp := maxHP(pokemon, level)
+	config.Seen[row.pokemon] = true
+	// The party grows fond of the player as they travel together
+	for _, id := range config.Party {
+		addFriendship(caughtByID(config.Caught, id), 1)
+	}
+	config.Wild = &WildPokemon{Name: row.pokemon, Level: level, Area: area.Name, Method: row.method, HP: hp, MaxHP: hp}
+	fmt.Printf("A wild %s (level %d) appeared!\n", row.pokemon, level)
+	fmt.Printf("Throw a Pokeball with catch %s.\n", row.pokemon)
+	// The draw moved the RNG and the Pokemon is seen, a resumed session continues from here
+	return saveProgress(config)
+}
+
+// encounterVersion returns version, or without one the first version listed for area
+// that has encounters with method, so the draw follows the chances of a single game.
+func encounterVersion(area pokeapi.LocationArea, version, method string) string {
+	if version != "" {
+		return version
+	}
+	for _, encounter := range area.PokemonEncounters {
+		for _, details := range encounter.VersionDetails {
+			for _, detail := range details.EncounterDetails {
+				if method == "" || detail.Method.Name == method {
+					return details.Version.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// defaultEncounterMethod walks through the grass when the area has any, and otherwise
+// uses the first method found there.
+func defaultEncounterMethod(rows []encounterRow) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	if slices.ContainsFunc(rows, func(row encounterRow) bool { return row.method == "walk" }) {
+		return "walk"
+	}
+	return rows[0].method
+}
+
+// pickEncounter draws a row weighted by its chance and a level within its range.
+// intN returns a random number in [0, n).
+func pickEncounter(rows []encounterRow, intN func(n int) int) (encounterRow, int) {
+	total := 0
+	for _, row := range rows {
+		total += row.chance
+	}
+	picked := rows[len(rows)-1]
+	if total > 0 {
+		roll := intN(total)
+		for _, row := range rows {
+			if roll < row.chance {
+				picked = row
+				break
+			}
+			roll -= row.chance
+		}
+	}
+	return picked, picked.minLevel + intN(picked.maxLevel-picked.minLevel+1)
+}
+
+// maxHP computes the HP of a wild Pokemon at a level, leaving out IVs.
+func maxHP(pokemon pokeapi.Pokemon, level int) int {
+	return calcStat(pokemon, "hp", 0, level)
+}
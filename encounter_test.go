@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+func TestPickEncounter(t *testing.T) {
+	rows := []encounterRow{
+		{pokemon: "bidoof", minLevel: 2, maxLevel: 4, chance: 60},
+		{pokemon: "starly", minLevel: 3, maxLevel: 3, chance: 30},
+		{pokemon: "kricketot", minLevel: 5, maxLevel: 6, chance: 10},
+	}
+	cases := []struct {
+		roll    int // drawn for the Pokemon, the level roll is always the highest
+		pokemon string
+		level   int
+	}{
+		{roll: 0, pokemon: "bidoof", level: 4},
+		{roll: 59, pokemon: "bidoof", level: 4},
+		{roll: 60, pokemon: "starly", level: 3},
+		{roll: 89, pokemon: "starly", level: 3},
+		{roll: 99, pokemon: "kricketot", level: 6},
+	}
+	for _, c := range cases {
+		rolls := 0
+		intN := func(n int) int {
+			rolls++
+			if rolls == 1 {
+				if n != 100 {
+					t.Errorf("expected the chances to add up to 100, got %d", n)
+				}
+				return c.roll
+			}
+			return n - 1
+		}
+		row, level := pickEncounter(rows, intN)
+		if row.pokemon != c.pokemon || level != c.level {
+			t.Errorf("roll %d: got %s at level %d, expected %s at level %d", c.roll, row.pokemon, level, c.pokemon, c.level)
+		}
+	}
+}
+
+func TestEncounterVersion(t *testing.T) {
+	var area pokeapi.LocationArea
+	err := json.Unmarshal([]byte(`{"pokemon_encounters": [
+		{"pokemon": {"name": "zubat"}, "version_details": [
+			{"version": {"name": "diamond"}, "encounter_details": [{"chance": 30, "method": {"name": "walk"}}]},
+			{"version": {"name": "pearl"}, "encounter_details": [{"chance": 40, "method": {"name": "walk"}}]}]},
+		{"pokemon": {"name": "tentacool"}, "version_details": [
+			{"version": {"name": "pearl"}, "encounter_details": [{"chance": 60, "method": {"name": "surf"}}]}]}]}`), &area)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		version, method string
+		expected        string
+	}{
+		{expected: "diamond"},
+		{method: "walk", expected: "diamond"},
+		{method: "surf", expected: "pearl"},
+		{version: "platinum", expected: "platinum"},
+		{method: "old-rod", expected: ""},
+	}
+	for _, c := range cases {
+		if got := encounterVersion(area, c.version, c.method); got != c.expected {
+			t.Errorf("encounterVersion(%q, %q) = %q, expected %q", c.version, c.method, got, c.expected)
+		}
+	}
+}
+
+func TestEncounterThenCatch(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+
+	run := func(command func(context.Context, *Config) error, args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := command(context.Background(), &config); err != nil {
+				t.Errorf("command %v: %v", args, err)
+			}
+		})
+	}
+
+	if output := run(commandEncounter); !strings.Contains(output, "Explore a location area first") {
+		t.Errorf("expected encounter to need an explored area, got:\n%s", output)
+	}
+	if output := run(commandCatch); !strings.Contains(output, "no wild Pokemon around") {
+		t.Errorf("expected catch to need an encounter, got:\n%s", output)
+	}
+
+	config.Caught = []CaughtPokemon{{ID: 1, Pokemon: pokeapi.Pokemon{Name: "pikachu"}, Friendship: 70}}
+	config.Party = []int{1}
+	config.AreaName = "eterna-forest-area"
+	run(commandExplore, "eterna-forest-area")
+	run(commandEncounter)
+	if config.Caught[0].Friendship != 71 {
+		t.Errorf("friendship = %d, expected the party to grow fonder with every encounter", config.Caught[0].Friendship)
+	}
+	config.Caught, config.Party = nil, nil
+	wild := config.Wild
+	if wild == nil {
+		t.Fatalf("expected a wild Pokemon to appear")
+	}
+	// Every Pokemon of eterna-forest-area is met walking at level 9 to 12
+	if wild.Area != "eterna-forest-area" || wild.Method != "walk" || wild.Level < 9 || wild.Level > 12 {
+		t.Errorf("unexpected wild Pokemon %+v", wild)
+	}
+
+	config.PokemonName = "magikarp"
+	if output := run(commandCatch); !strings.Contains(output, "You have not encountered magikarp") {
+		t.Errorf("expected only the wild Pokemon to be catchable, got:\n%s", output)
+	}
+
+	// Canalave City only has fishing and surfing, the old rod only finds magikarp
+	config.AreaName = "canalave-city-area"
+	run(commandExplore, "canalave-city-area")
+	run(commandEncounter, "--method", "old-rod")
+	if config.Wild == nil || config.Wild.Name != "magikarp" || config.Wild.Method != "old-rod" {
+		t.Errorf("expected to fish up a magikarp, got %+v", config.Wild)
+	}
+	if output := run(commandEncounter, "--method", "walk"); !strings.Contains(output, "No wild Pokemon can be found in canalave-city-area with walk") {
+		t.Errorf("expected no grass in canalave-city-area, got:\n%s", output)
+	}
+}
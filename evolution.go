@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+const (
+	maxFriendship     = 255
+	defaultFriendship = 70 // base friendship of most species, given to Pokemon caught before friendship was recorded
+)
+
+// startingItems is the bag of evolution items of a new Pokedex, one of each Gen IV stone.
+func startingItems() map[string]int {
+	return map[string]int{
+		"fire-stone": 1, "water-stone": 1, "thunder-stone": 1, "leaf-stone": 1, "moon-stone": 1,
+		"sun-stone": 1, "shiny-stone": 1, "dusk-stone": 1, "dawn-stone": 1,
+	}
+}
+
+// evolutionTrigger is what happened to a caught Pokemon that might make it evolve.
+type evolutionTrigger struct {
+	name string    // "level-up", "use-item" or "trade", as PokeAPI names them
+	item string    // item used, for "use-item"
+	now  time.Time // when it happened, for evolutions at a time of day
+}
+
+// commandEvolve evolves a caught Pokemon: "evolve <name|id>" checks whether leveling up
+// would evolve it now, "evolve <name|id> --item <item>" uses an item from the bag on it
+// and "evolve <name|id> --trade" trades it to a friend, who trades it back.
+func commandEvolve(ctx context.Context, config *Config) error {
+	if len(config.Args) == 0 {
+		fmt.Println("Usage: evolve <name|id> [--item <item> | --trade]")
+		return nil
+	}
+	c := findCaught(config.Caught, config.Args[0])
+	if c == nil {
+		fmt.Printf("You have not caught %s.\n", config.Args[0])
+		return nil
+	}
+
+	trigger := evolutionTrigger{name: "level-up", now: time.Now()}
+	args := config.Args[1:]
+	if len(args) > 0 {
+		flag, value, hasValue := strings.Cut(args[0], "=")
+		if !hasValue && len(args) > 1 {
+			value = args[1]
+		}
+		switch {
+		case flag == "--trade" && !hasValue:
+			trigger.name = "trade"
+		case flag == "--item" && value != "":
+			trigger.name, trigger.item = "use-item", value
+		default:
+			fmt.Println("Usage: evolve <name|id> [--item <item> | --trade]")
+			return nil
+		}
+	}
+	if trigger.item != "" && config.Items[trigger.item] <= 0 {
+		fmt.Printf("You have no %s.\n", trigger.item)
+		return nil
+	}
+
+	stages, err := nextEvolutions(ctx, config.Client, c.Pokemon)
+	if err != nil {
+		return err
+	}
+	evolved, err := tryEvolve(ctx, config, c, stages, trigger)
+	if err != nil {
+		return err
+	}
+	if evolved {
+		if trigger.item != "" {
+			config.Items[trigger.item]--
+		}
+		return saveProgress(config)
+	}
+
+	if trigger.item != "" {
+		fmt.Printf("The %s had no effect on %s.\n", trigger.item, c.DisplayName())
+	}
+	if len(stages) == 0 {
+		fmt.Printf("%s does not evolve any further.\n", c.Pokemon.Name)
+		return nil
+	}
+	fmt.Printf("%s is not ready to evolve. It evolves into:\n", c.DisplayName())
+	for _, stage := range stages {
+		for _, detail := range stage.EvolutionDetails {
+			fmt.Printf("- %s %s\n", stage.Species.Name, describeEvolution(detail))
+		}
+	}
+	return nil
+}
+
+// findCaught resolves a caught Pokemon by ID, like "3" or "#3", or by name, taking
+// the first one caught of a species. It returns nil if there is none.
+func findCaught(caught []CaughtPokemon, nameOrID string) *CaughtPokemon {
+	if id, err := strconv.Atoi(strings.TrimPrefix(nameOrID, "#")); err == nil {
+		return caughtByID(caught, id)
+	}
+	if found := caughtNamed(caught, nameOrID); len(found) > 0 {
+		return found[0]
+	}
+	return nil
+}
+
+// nextEvolutions fetches the evolution chain of pokemon and returns the stages it evolves into.
+func nextEvolutions(ctx context.Context, client *pokeapi.Client, pokemon pokeapi.Pokemon) ([]pokeapi.EvolutionStage, error) {
+	species, err := client.GetPokemonSpecies(ctx, pokemon.Species.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching species data: %w", describeAPIError(err))
+	}
+	chain, err := client.GetEvolutionChain(ctx, evolutionChainID(species))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching evolution chain: %w", describeAPIError(err))
+	}
+
+	stages := []pokeapi.EvolutionStage{chain.Chain}
+	for len(stages) > 0 {
+		stage := stages[0]
+		if stage.Species.Name == species.Name {
+			return stage.EvolvesTo, nil
+		}
+		stages = append(stages[1:], stage.EvolvesTo...)
+	}
+	return nil, nil
+}
+
+// evolutionChainID is the ID of the evolution chain of species, taken from its URL.
+func evolutionChainID(species pokeapi.PokemonSpecies) string {
+	return path.Base(strings.TrimRight(species.EvolutionChain.URL, "/"))
+}
+
+// tryEvolve evolves c into the first of stages whose conditions trigger meets.
+func tryEvolve(ctx context.Context, config *Config, c *CaughtPokemon, stages []pokeapi.EvolutionStage, trigger evolutionTrigger) (bool, error) {
+	for _, stage := range stages {
+		for _, detail := range stage.EvolutionDetails {
+			if evolutionMet(*c, detail, trigger) {
+				return true, evolve(ctx, config, c, stage.Species.Name)
+			}
+		}
+	}
+	return false, nil
+}
+
+// evolutionMet reports whether detail lets c evolve on trigger. Conditions the Pokedex
+// does not keep track of, like held items or known moves, are never met.
+func evolutionMet(c CaughtPokemon, detail pokeapi.EvolutionDetail, trigger evolutionTrigger) bool {
+	switch {
+	case detail.Trigger.Name != trigger.name:
+		return false
+	case detail.Item != nil && detail.Item.Name != trigger.item:
+		return false
+	case detail.MinLevel != nil && c.Level < *detail.MinLevel:
+		return false
+	case detail.MinHappiness != nil && c.Friendship < *detail.MinHappiness:
+		return false
+	case detail.TimeOfDay != "" && detail.TimeOfDay != timeOfDay(trigger.now):
+		return false
+	}
+	return !untrackedConditions(detail)
+}
+
+func untrackedConditions(detail pokeapi.EvolutionDetail) bool {
+	return detail.HeldItem != nil || detail.KnownMove != nil || detail.KnownMoveType != nil ||
+		detail.Location != nil || detail.PartySpecies != nil || detail.PartyType != nil ||
+		detail.TradeSpecies != nil || detail.Gender != nil || detail.MinBeauty != nil ||
+		detail.MinAffection != nil || detail.RelativePhysicalStats != nil ||
+		detail.NeedsOverworldRain || detail.TurnUpsideDown
+}
+
+// timeOfDay is "day" from 4:00 to 20:00 and "night" otherwise, as in Gen IV.
+func timeOfDay(now time.Time) string {
+	if hour := now.Hour(); hour >= 4 && hour < 20 {
+		return "day"
+	}
+	return "night"
+}
+
+// describeEvolution explains the conditions of detail, like "at level 30" or "with a thunder-stone".
+func describeEvolution(detail pokeapi.EvolutionDetail) string {
+	var description string
+	switch {
+	case detail.Trigger.Name == "level-up" && detail.MinLevel != nil:
+		description = fmt.Sprintf("at level %d", *detail.MinLevel)
+	case detail.Trigger.Name == "level-up":
+		description = "by leveling up"
+	case detail.Trigger.Name == "use-item" && detail.Item != nil:
+		description = "with a " + detail.Item.Name
+	case detail.Trigger.Name == "trade":
+		description = "when traded"
+	default:
+		description = "by " + detail.Trigger.Name
+	}
+	if detail.MinHappiness != nil {
+		description += fmt.Sprintf(" with friendship %d", *detail.MinHappiness)
+	}
+	switch detail.TimeOfDay {
+	case "day":
+		description += " during the day"
+	case "night":
+		description += " at night"
+	}
+	if untrackedConditions(detail) {
+		description += ", under conditions the Pokedex cannot check"
+	}
+	return description
+}
+
+// evolve turns c into the default Pokemon of species, keeping its nickname, level,
+// IVs and catch details. It learns the moves the new species learns at its level.
+func evolve(ctx context.Context, config *Config, c *CaughtPokemon, species string) error {
+	evolution, err := config.Client.GetPokemonSpecies(ctx, species)
+	if err != nil {
+		return fmt.Errorf("error fetching species data: %w", describeAPIError(err))
+	}
+	name := evolution.Name
+	for _, variety := range evolution.Varieties {
+		if variety.IsDefault {
+			name = variety.Pokemon.Name
+		}
+	}
+	pokemon, err := config.Client.GetPokemon(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokemon named %s", name)
+	}
+	if err != nil {
+		return fmt.Errorf("error fetching Pokemon data: %w", describeAPIError(err))
+	}
+
+	fmt.Printf("What? %s is evolving!\n", c.DisplayName())
+	previous := c.Pokemon.Name
+	c.EvolvedFrom = append(c.EvolvedFrom, previous)
+	c.Pokemon = pokemon
+	updateMaxHP(c)
+	config.Seen[pokemon.Name] = true
+	if c.Nickname != "" {
+		fmt.Printf("Congratulations! %s evolved from %s into %s!\n", c.Nickname, previous, pokemon.Name)
+	} else {
+		fmt.Printf("Congratulations! Your %s evolved into %s!\n", previous, pokemon.Name)
+	}
+	learnMoves(c, config.VersionGroup)
+	return nil
+}
+
+// levelUpFriendship is the friendship gained by leveling up, less as it grows, as in Gen IV.
+func levelUpFriendship(friendship int) int {
+	switch {
+	case friendship < 100:
+		return 5
+	case friendship < 200:
+		return 3
+	}
+	return 2
+}
+
+func addFriendship(c *CaughtPokemon, friendship int) {
+	c.Friendship = min(c.Friendship+friendship, maxFriendship)
+}
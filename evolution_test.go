@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+func TestEvolutionMet(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	detail := func(trigger string, set func(*pokeapi.EvolutionDetail)) pokeapi.EvolutionDetail {
+		var d pokeapi.EvolutionDetail
+		d.Trigger.Name = trigger
+		if set != nil {
+			set(&d)
+		}
+		return d
+	}
+	noon := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	caught := CaughtPokemon{Level: 20, Friendship: 220}
+
+	cases := []struct {
+		name     string
+		detail   pokeapi.EvolutionDetail
+		trigger  evolutionTrigger
+		expected bool
+	}{
+		{
+			name:     "min level reached",
+			detail:   detail("level-up", func(d *pokeapi.EvolutionDetail) { d.MinLevel = intPtr(20) }),
+			trigger:  evolutionTrigger{name: "level-up", now: noon},
+			expected: true,
+		},
+		{
+			name:    "min level not reached",
+			detail:  detail("level-up", func(d *pokeapi.EvolutionDetail) { d.MinLevel = intPtr(21) }),
+			trigger: evolutionTrigger{name: "level-up", now: noon},
+		},
+		{
+			name:     "item used",
+			detail:   detail("use-item", func(d *pokeapi.EvolutionDetail) { d.Item = &pokeapi.NamedResource{Name: "thunder-stone"} }),
+			trigger:  evolutionTrigger{name: "use-item", item: "thunder-stone", now: noon},
+			expected: true,
+		},
+		{
+			name:    "other item used",
+			detail:  detail("use-item", func(d *pokeapi.EvolutionDetail) { d.Item = &pokeapi.NamedResource{Name: "thunder-stone"} }),
+			trigger: evolutionTrigger{name: "use-item", item: "water-stone", now: noon},
+		},
+		{
+			name:    "item evolution on level up",
+			detail:  detail("use-item", func(d *pokeapi.EvolutionDetail) { d.Item = &pokeapi.NamedResource{Name: "thunder-stone"} }),
+			trigger: evolutionTrigger{name: "level-up", now: noon},
+		},
+		{
+			name:     "traded",
+			detail:   detail("trade", nil),
+			trigger:  evolutionTrigger{name: "trade", now: noon},
+			expected: true,
+		},
+		{
+			name:    "traded holding an item",
+			detail:  detail("trade", func(d *pokeapi.EvolutionDetail) { d.HeldItem = &pokeapi.NamedResource{Name: "metal-coat"} }),
+			trigger: evolutionTrigger{name: "trade", now: noon},
+		},
+		{
+			name: "friendship during the day",
+			detail: detail("level-up", func(d *pokeapi.EvolutionDetail) {
+				d.MinHappiness, d.TimeOfDay = intPtr(220), "day"
+			}),
+			trigger:  evolutionTrigger{name: "level-up", now: noon},
+			expected: true,
+		},
+		{
+			name: "friendship at night",
+			detail: detail("level-up", func(d *pokeapi.EvolutionDetail) {
+				d.MinHappiness, d.TimeOfDay = intPtr(220), "day"
+			}),
+			trigger: evolutionTrigger{name: "level-up", now: midnight},
+		},
+		{
+			name:    "friendship too low",
+			detail:  detail("level-up", func(d *pokeapi.EvolutionDetail) { d.MinHappiness = intPtr(221) }),
+			trigger: evolutionTrigger{name: "level-up", now: noon},
+		},
+	}
+	for _, c := range cases {
+		if got := evolutionMet(caught, c.detail, c.trigger); got != c.expected {
+			t.Errorf("%s: evolutionMet() = %v, expected %v", c.name, got, c.expected)
+		}
+	}
+}
+
+func TestCommandEvolve(t *testing.T) {
+	client := newMockClient(t)
+	config := newTestConfig()
+	config.Client = client
+	pikachu, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sparky := newCaughtPokemon(1, pikachu, 12, "", nil)
+	sparky.Nickname = "sparky"
+	sparky.Area = "viridian-forest-area"
+	sparky.HP = 20
+	config.Caught = []CaughtPokemon{sparky}
+
+	run := func(args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := commandEvolve(context.Background(), &config); err != nil {
+				t.Errorf("commandEvolve(%v): %v", args, err)
+			}
+		})
+	}
+
+	if output := run("sparky"); !strings.Contains(output, "sparky is not ready to evolve. It evolves into:\n- raichu with a thunder-stone\n") {
+		t.Errorf("expected the ways to evolve to be listed, got:\n%s", output)
+	}
+	if output := run("1", "--item", "water-stone"); !strings.Contains(output, "The water-stone had no effect on sparky") || config.Items["water-stone"] != 1 {
+		t.Errorf("expected the water stone to be kept, got:\n%s", output)
+	}
+	config.Items["thunder-stone"] = 0
+	if output := run("sparky", "--item=thunder-stone"); !strings.Contains(output, "You have no thunder-stone") {
+		t.Errorf("expected a missing item to be reported, got:\n%s", output)
+	}
+
+	config.Items["thunder-stone"] = 1
+	output := run("sparky", "--item", "thunder-stone")
+	if !strings.Contains(output, "Congratulations! sparky evolved from pikachu into raichu!") {
+		t.Errorf("expected sparky to evolve, got:\n%s", output)
+	}
+	raichu := config.Caught[0]
+	if raichu.Pokemon.Name != "raichu" || raichu.ID != 1 || raichu.Nickname != "sparky" || raichu.Area != "viridian-forest-area" || raichu.Level != 12 {
+		t.Errorf("expected the individual to be kept, got %+v", raichu)
+	}
+	// Base HP 60 instead of 35 raises the max HP from 30 to 36 at level 12
+	if !slices.Equal(raichu.EvolvedFrom, []string{"pikachu"}) || raichu.MaxHP != 36 || raichu.HP != 26 {
+		t.Errorf("evolved from %v with HP %d/%d", raichu.EvolvedFrom, raichu.HP, raichu.MaxHP)
+	}
+	if config.Items["thunder-stone"] != 0 || !config.Seen["raichu"] || len(caughtNamed(config.Caught, "pikachu")) != 0 {
+		t.Errorf("expected the stone to be used and the Pokedex to list raichu")
+	}
+
+	if output := run("sparky"); !strings.Contains(output, "raichu does not evolve any further") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+}
+
+func TestLevelUpEvolution(t *testing.T) {
+	client := newMockClient(t)
+	config := newTestConfig()
+	config.Client = client
+	magikarp, err := client.GetPokemon(context.Background(), "magikarp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// magikarp grows on the slow curve, level 19 needs 8573 experience and level 20 needs 10000
+	caught := newCaughtPokemon(1, magikarp, 19, "", nil)
+	caught.Experience = 8573
+	config.Caught = []CaughtPokemon{caught}
+	config.Party = []int{1}
+
+	output := captureOutput(t, func() {
+		if err := awardExperience(context.Background(), &config, pokeapi.Pokemon{BaseExperience: 1000}, 10); err != nil {
+			t.Errorf("awardExperience: %v", err)
+		}
+	})
+	gyarados := config.Caught[0]
+	if gyarados.Pokemon.Name != "gyarados" || gyarados.Level != 20 || !strings.Contains(output, "Your magikarp evolved into gyarados!") {
+		t.Errorf("expected magikarp to evolve at level 20, got:\n%s", output)
+	}
+	// gyarados learns leer at level 20
+	if !slices.Equal(gyarados.Moves, []string{"splash", "tackle", "leer"}) {
+		t.Errorf("moves = %v", gyarados.Moves)
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// commandExplore lists the Pokemon found in a location area, given by name or numeric ID.
+// The name of a location explores every area of that location, e.g. "explore great-marsh".
+func commandExplore(ctx context.Context, config *Config) error {
+	if config.AreaName == "" && config.AreaID == 0 {
+		fmt.Println("Please provide an area name or ID to explore.")
+		return nil
+	}
+	var flags []string
+	if len(config.Args) > 1 {
+		flags = config.Args[1:] // the first argument is the area
+	}
+	opts, ok := parseExploreArgs(flags)
+	if !ok {
+		return nil
+	}
+	if opts.version == "" {
+		opts.version = config.Version
+	}
+
+	if config.AreaID != 0 {
+		area, err := config.Client.GetLocationArea(ctx, strconv.Itoa(config.AreaID))
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			fmt.Printf("no location area with ID %d\n", config.AreaID)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error fetching area data: %w", describeAPIError(err))
+		}
+		enterArea(config, area.Name)
+		fmt.Printf("Exploring %s...\n", area.Name)
+		displayEncounters(area, "", opts)
+		return nil
+	}
+
+	fmt.Printf("Exploring %s...\n", config.AreaName)
+	area, err := config.Client.GetLocationArea(ctx, config.AreaName)
+	if err == nil {
+		enterArea(config, area.Name)
+		displayEncounters(area, "", opts)
+		return nil
+	}
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("error fetching area data: %w", describeAPIError(err))
+	}
+
+	// Not an area, maybe the location the areas belong to
+	location, err := config.Client.GetLocation(ctx, config.AreaName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("no location or location area named %s\n", config.AreaName)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error fetching location data: %w", describeAPIError(err))
+	}
+	for _, ref := range location.Areas {
+		fmt.Printf("%s:\n", ref.Name)
+		area, err := config.Client.GetLocationArea(ctx, ref.Name)
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			// Offline bundles may hold the location without all of its areas
+			fmt.Println("  No data for this area.")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error fetching area data: %w", describeAPIError(err))
+		}
+		displayEncounters(area, "  ", opts)
+	}
+	// Encounters need a single area to happen in
+	if len(location.Areas) == 1 {
+		enterArea(config, location.Areas[0].Name)
+	} else {
+		enterArea(config, "")
+		fmt.Println("Explore one of these areas to look for wild Pokemon there.")
+	}
+	return nil
+}
+
+// enterArea makes area the one encounters happen in, empty for none.
+// A wild Pokemon met in another area is left behind.
+func enterArea(config *Config, area string) {
+	if area != config.CurrentArea {
+		config.Wild = nil
+	}
+	config.CurrentArea = area
+}
+
+// exploreOptions are the explore flags that narrow down and order the encounter table.
+type exploreOptions struct {
+	version  string // only encounters in this game version
+	method   string // only encounters with this method, e.g. "surf"
+	byRarity bool   // rarest encounters first
+}
+
+// parseExploreArgs reads the flags following the area name.
+func parseExploreArgs(args []string) (exploreOptions, bool) {
+	var opts exploreOptions
+	for i := 0; i < len(args); i++ {
+		arg, value, hasValue := strings.Cut(args[i], "=")
+		if arg != "--version" && arg != "--method" && arg != "--sort" {
+			fmt.Printf("Unknown explore argument %q.\n", args[i])
+			fmt.Println("Usage: explore <area|location|id> [--version <version>] [--method <method>] [--sort rarity]")
+			return opts, false
+		}
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+			i++
+		}
+		if value == "" {
+			fmt.Printf("%s needs a value.\n", arg)
+			return opts, false
+		}
+
+		switch arg {
+		case "--version":
+			opts.version = value
+		case "--method":
+			opts.method = value
+		case "--sort":
+			if value != "rarity" {
+				fmt.Printf("Cannot sort by %q, only by rarity.\n", value)
+				return opts, false
+			}
+			opts.byRarity = true
+		}
+	}
+	return opts, true
+}
+
+// encounterRow is one line of the explore table: a Pokemon found with one method,
+// at the same chance and levels in every listed version.
+type encounterRow struct {
+	pokemon  string
+	method   string
+	minLevel int
+	maxLevel int
+	chance   int // percent
+	versions []string
+}
+
+// encounterRows flattens the encounters of an area into table rows.
+// Chances of the same method within a version add up, as PokeAPI lists one entry per encounter slot.
+func encounterRows(area pokeapi.LocationArea, opts exploreOptions) []encounterRow {
+	var rows []encounterRow
+	for _, encounter := range area.PokemonEncounters {
+		for _, details := range encounter.VersionDetails {
+			if opts.version != "" && details.Version.Name != opts.version {
+				continue
+			}
+			var byMethod []encounterRow
+			for _, detail := range details.EncounterDetails {
+				if opts.method != "" && detail.Method.Name != opts.method {
+					continue
+				}
+				i := slices.IndexFunc(byMethod, func(row encounterRow) bool { return row.method == detail.Method.Name })
+				if i < 0 {
+					byMethod = append(byMethod, encounterRow{
+						pokemon:  encounter.Pokemon.Name,
+						method:   detail.Method.Name,
+						minLevel: detail.MinLevel,
+						maxLevel: detail.MaxLevel,
+					})
+					i = len(byMethod) - 1
+				}
+				row := &byMethod[i]
+				row.minLevel = min(row.minLevel, detail.MinLevel)
+				row.maxLevel = max(row.maxLevel, detail.MaxLevel)
+				row.chance += detail.Chance
+			}
+
+			// Versions with identical encounters share a row
+			for _, row := range byMethod {
+				i := slices.IndexFunc(rows, func(r encounterRow) bool {
+					return r.pokemon == row.pokemon && r.method == row.method && r.chance == row.chance &&
+						r.minLevel == row.minLevel && r.maxLevel == row.maxLevel
+				})
+				if i < 0 {
+					rows = append(rows, row)
+					i = len(rows) - 1
+				}
+				rows[i].versions = append(rows[i].versions, details.Version.Name)
+			}
+		}
+	}
+	if opts.byRarity {
+		slices.SortStableFunc(rows, func(a, b encounterRow) int { return a.chance - b.chance })
+	}
+	return rows
+}
+
+// displayEncounters prints the encounter table of an area, each line starting with indent.
+func displayEncounters(area pokeapi.LocationArea, indent string, opts exploreOptions) {
+	rows := encounterRows(area, opts)
+	if len(rows) == 0 {
+		if opts.version != "" || opts.method != "" {
+			fmt.Printf("%sNo Pokemon found with these filters.\n", indent)
+		} else {
+			fmt.Printf("%sNo Pokemon found.\n", indent)
+		}
+		return
+	}
+
+	fmt.Printf("%sFound Pokemon:\n", indent)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%sPOKEMON\tLEVELS\tMETHOD\tCHANCE\tVERSIONS\n", indent)
+	for _, row := range rows {
+		levels := strconv.Itoa(row.minLevel)
+		if row.maxLevel != row.minLevel {
+			levels += "-" + strconv.Itoa(row.maxLevel)
+		}
+		fmt.Fprintf(w, "%s%s\t%s\t%s\t%d%%\t%s\n", indent, row.pokemon, levels, row.method, row.chance, strings.Join(row.versions, ", "))
+	}
+	w.Flush()
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+func TestCommandExploreByIDAndLocation(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+
+	cases := []struct {
+		name     string
+		areaName string
+		areaID   int
+		expected []string
+	}{
+		{name: "area ID", areaID: 9, expected: []string{"Exploring eterna-forest-area", "budew"}},
+		{name: "location", areaName: "great-marsh", expected: []string{"great-marsh-area-1:\n  Found Pokemon:", "  bidoof", "great-marsh-area-6:", "  tentacool"}},
+		{name: "unknown ID", areaID: 999, expected: []string{"no location area with ID 999"}},
+		{name: "unknown name", areaName: "nowhere", expected: []string{"no location or location area named nowhere"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config.AreaName, config.AreaID = c.areaName, c.areaID
+			output := captureOutput(t, func() {
+				if err := commandExplore(context.Background(), &config); err != nil {
+					t.Errorf("commandExplore: %v", err)
+				}
+			})
+			for _, text := range c.expected {
+				if !strings.Contains(output, text) {
+					t.Errorf("expected explore output to contain %q, got:\n%s", text, output)
+				}
+			}
+		})
+	}
+}
+
+func TestEncounterRows(t *testing.T) {
+	// Two slots of the same method add up, and pearl differs from diamond
+	var area pokeapi.LocationArea
+	err := json.Unmarshal([]byte(`{"pokemon_encounters": [
+		{"pokemon": {"name": "zubat"}, "version_details": [
+			{"version": {"name": "diamond"}, "encounter_details": [
+				{"min_level": 10, "max_level": 12, "chance": 20, "method": {"name": "walk"}},
+				{"min_level": 8, "max_level": 11, "chance": 10, "method": {"name": "walk"}},
+				{"min_level": 15, "max_level": 15, "chance": 5, "method": {"name": "surf"}}]},
+			{"version": {"name": "platinum"}, "encounter_details": [
+				{"min_level": 10, "max_level": 12, "chance": 20, "method": {"name": "walk"}},
+				{"min_level": 8, "max_level": 11, "chance": 10, "method": {"name": "walk"}}]},
+			{"version": {"name": "pearl"}, "encounter_details": [
+				{"min_level": 10, "max_level": 12, "chance": 40, "method": {"name": "walk"}}]}]},
+		{"pokemon": {"name": "geodude"}, "version_details": [
+			{"version": {"name": "pearl"}, "encounter_details": [
+				{"min_level": 9, "max_level": 9, "chance": 1, "method": {"name": "walk"}}]}]}]}`), &area)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		opts     exploreOptions
+		expected []encounterRow
+	}{
+		{name: "all", expected: []encounterRow{
+			{pokemon: "zubat", method: "walk", minLevel: 8, maxLevel: 12, chance: 30, versions: []string{"diamond", "platinum"}},
+			{pokemon: "zubat", method: "surf", minLevel: 15, maxLevel: 15, chance: 5, versions: []string{"diamond"}},
+			{pokemon: "zubat", method: "walk", minLevel: 10, maxLevel: 12, chance: 40, versions: []string{"pearl"}},
+			{pokemon: "geodude", method: "walk", minLevel: 9, maxLevel: 9, chance: 1, versions: []string{"pearl"}},
+		}},
+		{name: "version", opts: exploreOptions{version: "pearl", byRarity: true}, expected: []encounterRow{
+			{pokemon: "geodude", method: "walk", minLevel: 9, maxLevel: 9, chance: 1, versions: []string{"pearl"}},
+			{pokemon: "zubat", method: "walk", minLevel: 10, maxLevel: 12, chance: 40, versions: []string{"pearl"}},
+		}},
+		{name: "method", opts: exploreOptions{method: "surf"}, expected: []encounterRow{
+			{pokemon: "zubat", method: "surf", minLevel: 15, maxLevel: 15, chance: 5, versions: []string{"diamond"}},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rows := encounterRows(area, c.opts)
+			equal := slices.EqualFunc(rows, c.expected, func(a, b encounterRow) bool {
+				return a.pokemon == b.pokemon && a.method == b.method && a.minLevel == b.minLevel &&
+					a.maxLevel == b.maxLevel && a.chance == b.chance && slices.Equal(a.versions, b.versions)
+			})
+			if !equal {
+				t.Errorf("encounterRows() = %+v, expected %+v", rows, c.expected)
+			}
+		})
+	}
+}
+
+func TestCommandExploreFlags(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	config.AreaName = "canalave-city-area"
+	config.Args = []string{"canalave-city-area", "--method", "surf", "--version=pearl", "--sort", "rarity"}
+
+	output := captureOutput(t, func() {
+		if err := commandExplore(context.Background(), &config); err != nil {
+			t.Errorf("commandExplore: %v", err)
+		}
+	})
+	var names []string
+	for _, line := range strings.Split(output, "\n")[3:] {
+		if fields := strings.Fields(line); len(fields) == 5 {
+			names = append(names, fields[0])
+			if fields[2] != "surf" || fields[4] != "pearl" {
+				t.Errorf("unexpected row %q", line)
+			}
+		}
+	}
+	expected := []string{"tentacruel", "pelipper", "wingull", "tentacool"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("expected rows for %v, got:\n%s", expected, output)
+	}
+
+	config.Args = []string{"canalave-city-area", "--sort", "name"}
+	output = captureOutput(t, func() {
+		if err := commandExplore(context.Background(), &config); err != nil {
+			t.Errorf("commandExplore: %v", err)
+		}
+	})
+	if !strings.Contains(output, "only by rarity") {
+		t.Errorf("expected an unsupported sort to be reported, got:\n%s", output)
+	}
+}
+
+func TestCommandExploreFollowsVersion(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	config.AreaName = "eterna-forest-area"
+	config.Version = "platinum"
+
+	output := captureOutput(t, func() {
+		if err := commandExplore(context.Background(), &config); err != nil {
+			t.Errorf("commandExplore: %v", err)
+		}
+	})
+	if !strings.Contains(output, "platinum") || strings.Contains(output, "diamond") {
+		t.Errorf("expected only platinum encounters, got:\n%s", output)
+	}
+}
+
+func TestCommandExploreLeavesArea(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	run := func(command func(context.Context, *Config) error, args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := command(context.Background(), &config); err != nil {
+				t.Errorf("%v: %v", args, err)
+			}
+		})
+	}
+
+	config.AreaName = "eterna-forest-area"
+	run(commandExplore, "eterna-forest-area")
+	run(commandEncounter)
+	if config.CurrentArea != "eterna-forest-area" || config.Wild == nil {
+		t.Fatalf("expected an encounter in eterna-forest-area, got area %q and %+v", config.CurrentArea, config.Wild)
+	}
+
+	// great-marsh has several areas, so there is no area to look in anymore
+	config.AreaName = "great-marsh"
+	run(commandExplore, "great-marsh")
+	if config.CurrentArea != "" || config.Wild != nil {
+		t.Errorf("expected to leave eterna-forest-area behind, got area %q and %+v", config.CurrentArea, config.Wild)
+	}
+	if output := run(commandEncounter); !strings.Contains(output, "Explore a location area first") {
+		t.Errorf("expected encounter to need a new area, got:\n%s", output)
+	}
+	if output := run(commandCatch); !strings.Contains(output, "There is no wild Pokemon around") {
+		t.Errorf("expected nothing to catch, got:\n%s", output)
+	}
+}
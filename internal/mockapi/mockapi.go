@@ -0,0 +1,32 @@
+// Package mockapi serves a small, realistic subset of PokeAPI from embedded fixtures,
+// so the CLI can be developed and tested without touching pokeapi.co.
+//
+// The fixtures live in data/ in the api-data layout and cover the Sinnoh locations
+// canalave-city, valley-windworks, eterna-forest and great-marsh, their areas,
+// every Pokemon found there, their species, growth rates and evolution chains.
+package mockapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+//go:embed data
+var data embed.FS
+
+// Handler serves the embedded fixtures under /api/v2/.
+func Handler() http.Handler {
+	return pokeapi.NewBundleTransport(FS())
+}
+
+// FS returns the embedded fixtures, with the api/v2 tree at the root.
+func FS() fs.FS {
+	root, err := fs.Sub(data, "data")
+	if err != nil {
+		panic(err) // the directory is embedded at build time
+	}
+	return root
+}
@@ -0,0 +1,105 @@
+package mockapi
+
+import (
+	"context"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+func newTestClient(t *testing.T) (*pokeapi.Client, string) {
+	t.Helper()
+	server := httptest.NewServer(Handler())
+	t.Cleanup(server.Close)
+	baseURL := server.URL + "/api/v2"
+	return pokeapi.NewClient(pokeapi.WithBaseURL(baseURL)), baseURL
+}
+
+func TestPaginationLinksPointAtServer(t *testing.T) {
+	client, baseURL := newTestClient(t)
+
+	page, err := client.ListLocationAreas(context.Background(), 0, 20)
+	if err != nil {
+		t.Fatalf("ListLocationAreas: %v", err)
+	}
+	if page.Count != 40 || len(page.Results) != 20 || page.Previous != nil {
+		t.Errorf("unexpected first page: count %d, %d results", page.Count, len(page.Results))
+	}
+	if page.Next == nil || !strings.HasPrefix(*page.Next, baseURL+"/location-area/") {
+		t.Fatalf("expected next to point at %s, got %v", baseURL, page.Next)
+	}
+
+	next, err := client.LocationAreaPage(context.Background(), *page.Next)
+	if err != nil {
+		t.Fatalf("LocationAreaPage: %v", err)
+	}
+	if next.Results[0].Name != "mt-coronet-1f-route-216" || next.Next != nil || next.Previous == nil {
+		t.Errorf("unexpected second page: %+v", next)
+	}
+}
+
+// Every area, Pokemon, species and chain referenced by the fixtures must be served too.
+func TestFixturesAreConsistent(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	for _, locationName := range []string{"canalave-city", "valley-windworks", "eterna-forest", "great-marsh"} {
+		location, err := client.GetLocation(ctx, locationName)
+		if err != nil {
+			t.Fatalf("GetLocation(%s): %v", locationName, err)
+		}
+		for _, ref := range location.Areas {
+			area, err := client.GetLocationArea(ctx, ref.Name)
+			if err != nil {
+				t.Fatalf("GetLocationArea(%s): %v", ref.Name, err)
+			}
+			if area.Location.Name != locationName {
+				t.Errorf("%s belongs to %s, expected %s", area.Name, area.Location.Name, locationName)
+			}
+			for _, encounter := range area.PokemonEncounters {
+				pokemon, err := client.GetPokemon(ctx, encounter.Pokemon.Name)
+				if err != nil {
+					t.Errorf("%s: GetPokemon(%s): %v", area.Name, encounter.Pokemon.Name, err)
+					continue
+				}
+				checkSpecies(t, client, pokemon.Species.Name)
+			}
+		}
+	}
+}
+
+func checkSpecies(t *testing.T, client *pokeapi.Client, name string) {
+	t.Helper()
+	ctx := context.Background()
+	species, err := client.GetPokemonSpecies(ctx, name)
+	if err != nil {
+		t.Errorf("species %s: %v", name, err)
+		return
+	}
+	rate, err := client.GetGrowthRate(ctx, species.GrowthRate.Name)
+	if err != nil {
+		t.Errorf("growth rate of %s: %v", name, err)
+	} else if len(rate.Levels) != 100 {
+		t.Errorf("growth rate %s has %d levels, expected 100", rate.Name, len(rate.Levels))
+	}
+
+	chainID := path.Base(strings.TrimRight(species.EvolutionChain.URL, "/"))
+	chain, err := client.GetEvolutionChain(ctx, chainID)
+	if err != nil {
+		t.Errorf("evolution chain of %s: %v", name, err)
+		return
+	}
+
+	// Every species in the chain is served as well, with a Pokemon of the same name
+	stages := []pokeapi.EvolutionStage{chain.Chain}
+	for len(stages) > 0 {
+		stage := stages[0]
+		stages = append(stages[1:], stage.EvolvesTo...)
+		if _, err := client.GetPokemon(ctx, stage.Species.Name); err != nil {
+			t.Errorf("chain %s references pokemon %s: %v", chainID, stage.Species.Name, err)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package pokeapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A bundle is a local copy of PokeAPI in the layout used by the api-data project:
+//
+//	api/v2/<resource>/index.json       lists every item of a resource
+//	api/v2/<resource>/<id>/index.json  holds a single item
+//
+// Item URLs inside a bundle are relative, e.g. "/api/v2/pokemon/25/".
+
+const bundleAPIPrefix = "api/v2"
+
+// BundleTransport is an http.RoundTripper that answers PokeAPI requests from a bundle
+// instead of the network, so the rest of the client works unchanged offline.
+// It is also an http.Handler, which serves the bundle as a PokeAPI mirror.
+type BundleTransport struct {
+	fsys fs.FS
+}
+
+// BundleList is the content of a resource's index.json.
+type BundleList struct {
+	Count    int          `json:"count"`
+	Next     *string      `json:"next"`
+	Previous *string      `json:"previous"`
+	Results  []BundleItem `json:"results"`
+}
+
+type BundleItem struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// OpenBundle opens a bundle stored in a directory or a .zip archive.
+// The api/v2 tree may also sit below a "data" directory or a single top-level directory,
+// as it does in a checkout or download of api-data.
+// The returned io.Closer releases the archive and must be closed when done.
+func OpenBundle(bundlePath string) (*BundleTransport, io.Closer, error) {
+	var fsys fs.FS
+	var closer io.Closer = io.NopCloser(nil)
+	if strings.HasSuffix(strings.ToLower(bundlePath), ".zip") {
+		archive, err := zip.OpenReader(bundlePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error opening bundle archive: %w", err)
+		}
+		fsys, closer = archive, archive
+	} else {
+		info, err := os.Stat(bundlePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error opening bundle: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, nil, fmt.Errorf("bundle %s is neither a directory nor a .zip archive", bundlePath)
+		}
+		fsys = os.DirFS(bundlePath)
+	}
+
+	root, err := findBundleRoot(fsys)
+	if err != nil {
+		closer.Close()
+		return nil, nil, fmt.Errorf("bundle %s: %w", bundlePath, err)
+	}
+	return NewBundleTransport(root), closer, nil
+}
+
+// NewBundleTransport serves a bundle whose api/v2 tree sits at the root of fsys.
+func NewBundleTransport(fsys fs.FS) *BundleTransport {
+	return &BundleTransport{fsys: fsys}
+}
+
+func findBundleRoot(fsys fs.FS) (fs.FS, error) {
+	candidates := []string{".", "data"}
+	if entries, err := fs.ReadDir(fsys, "."); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				candidates = append(candidates, entry.Name(), path.Join(entry.Name(), "data"))
+			}
+		}
+	}
+	for _, dir := range candidates {
+		if info, err := fs.Stat(fsys, path.Join(dir, bundleAPIPrefix)); err == nil && info.IsDir() {
+			return fs.Sub(fsys, dir)
+		}
+	}
+	return nil, errors.New("no api/v2 directory found")
+}
+
+func (t *BundleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	status, body := t.lookup(req.URL)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *BundleTransport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// Server requests only carry the path, pagination links need the full URL
+	u := *r.URL
+	u.Host = r.Host
+	u.Scheme = "http"
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+
+	status, body := t.lookup(&u)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// lookup resolves a PokeAPI URL to a status code and a JSON body.
+func (t *BundleTransport) lookup(u *url.URL) (int, []byte) {
+	_, rest, found := strings.Cut(u.Path, "/"+bundleAPIPrefix+"/")
+	if !found {
+		return http.StatusNotFound, notFoundBody
+	}
+	resource, nameOrID, _ := strings.Cut(strings.Trim(rest, "/"), "/")
+	if resource == "" || strings.Contains(nameOrID, "/") {
+		return http.StatusNotFound, notFoundBody
+	}
+
+	list, err := t.readList(resource)
+	if err != nil {
+		return http.StatusNotFound, notFoundBody
+	}
+	if nameOrID == "" {
+		return t.page(u, list)
+	}
+
+	id := nameOrID
+	if _, err := strconv.Atoi(nameOrID); err != nil {
+		// api-data only stores items by ID, so names go through the index
+		id = ""
+		for _, item := range list.Results {
+			if item.Name == nameOrID {
+				id = bundleItemID(item.URL)
+				break
+			}
+		}
+		if id == "" {
+			return http.StatusNotFound, notFoundBody
+		}
+	}
+
+	data, err := fs.ReadFile(t.fsys, path.Join(bundleAPIPrefix, resource, id, "index.json"))
+	if err != nil {
+		return http.StatusNotFound, notFoundBody
+	}
+	return http.StatusOK, data
+}
+
+var notFoundBody = []byte(`{"detail":"Not found."}`)
+
+func (t *BundleTransport) readList(resource string) (BundleList, error) {
+	var list BundleList
+	data, err := fs.ReadFile(t.fsys, path.Join(bundleAPIPrefix, resource, "index.json"))
+	if err != nil {
+		return list, err
+	}
+	err = json.Unmarshal(data, &list)
+	return list, err
+}
+
+// page slices a resource list the way PokeAPI paginates it,
+// with next and previous links pointing back at the requested host.
+func (t *BundleTransport) page(u *url.URL, list BundleList) (int, []byte) {
+	query := u.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	offset = max(offset, 0)
+
+	total := len(list.Results)
+	start := min(offset, total)
+	end := min(offset+limit, total)
+	response := BundleList{
+		Count:   total,
+		Results: list.Results[start:end],
+	}
+
+	pageURL := func(offset int) *string {
+		next := *u
+		q := next.Query()
+		q.Set("offset", strconv.Itoa(offset))
+		q.Set("limit", strconv.Itoa(limit))
+		next.RawQuery = q.Encode()
+		s := next.String()
+		return &s
+	}
+	if end < total {
+		response.Next = pageURL(end)
+	}
+	if offset > 0 {
+		response.Previous = pageURL(max(offset-limit, 0))
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return http.StatusInternalServerError, []byte(`{"detail":"Internal error."}`)
+	}
+	return http.StatusOK, data
+}
+
+// bundleItemID extracts the trailing ID from an item URL like "/api/v2/pokemon/25/".
+func bundleItemID(itemURL string) string {
+	return path.Base(strings.TrimRight(itemURL, "/"))
+}
+
+// WriteBundleItem stores one item in the bundle directory dir and adds it to the resource index.
+func WriteBundleItem(dir, resource string, id int, name string, data []byte) error {
+	itemDir := filepath.Join(dir, bundleAPIPrefix, resource, strconv.Itoa(id))
+	if err := os.MkdirAll(itemDir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(itemDir, "index.json"), data, 0o644); err != nil {
+		return err
+	}
+	return WriteBundleIndex(dir, resource, []BundleItem{{
+		Name: name,
+		URL:  fmt.Sprintf("/%s/%s/%d/", bundleAPIPrefix, resource, id),
+	}})
+}
+
+// WriteBundleIndex merges items into the index of a resource in the bundle directory dir.
+// Absolute PokeAPI URLs are rewritten to the relative form used in bundles.
+func WriteBundleIndex(dir, resource string, items []BundleItem) error {
+	indexPath := filepath.Join(dir, bundleAPIPrefix, resource, "index.json")
+	var list BundleList
+	if data, err := os.ReadFile(indexPath); err == nil {
+		if err := json.Unmarshal(data, &list); err != nil {
+			return fmt.Errorf("error decoding %s: %w", indexPath, err)
+		}
+	}
+
+	// Keyed by ID, as some resources like evolution-chain have no names
+	byID := make(map[string]BundleItem, len(list.Results)+len(items))
+	for _, item := range list.Results {
+		byID[bundleItemID(item.URL)] = item
+	}
+	for _, item := range items {
+		id := bundleItemID(item.URL)
+		byID[id] = BundleItem{
+			Name: item.Name,
+			URL:  fmt.Sprintf("/%s/%s/%s/", bundleAPIPrefix, resource, id),
+		}
+	}
+
+	list.Results = list.Results[:0]
+	for _, item := range byID {
+		list.Results = append(list.Results, item)
+	}
+	// Keep the PokeAPI order so pagination matches the online API
+	sort.Slice(list.Results, func(i, j int) bool {
+		a, _ := strconv.Atoi(bundleItemID(list.Results[i].URL))
+		b, _ := strconv.Atoi(bundleItemID(list.Results[j].URL))
+		return a < b
+	})
+	list.Count = len(list.Results)
+	list.Next, list.Previous = nil, nil
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(indexPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(indexPath, data, 0o644)
+}
@@ -0,0 +1,145 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func testBundle() fstest.MapFS {
+	var results string
+	for i := 1; i <= 5; i++ {
+		if i > 1 {
+			results += ","
+		}
+		results += fmt.Sprintf(`{"name":"area-%d","url":"/api/v2/location-area/%d/"}`, i, i)
+	}
+	return fstest.MapFS{
+		"data/api/v2/location-area/index.json":   {Data: []byte(`{"count":5,"next":null,"previous":null,"results":[` + results + `]}`)},
+		"data/api/v2/location-area/3/index.json": {Data: []byte(`{"id":3,"name":"area-3"}`)},
+		"data/api/v2/pokemon/index.json":         {Data: []byte(`{"count":1,"results":[{"name":"pikachu","url":"/api/v2/pokemon/25/"}]}`)},
+		"data/api/v2/pokemon/25/index.json":      {Data: []byte(`{"id":25,"name":"pikachu","base_experience":112}`)},
+	}
+}
+
+func newBundleClient(t *testing.T) *Client {
+	t.Helper()
+	root, err := findBundleRoot(testBundle())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(WithHTTPClient(&http.Client{Transport: NewBundleTransport(root)}))
+}
+
+func TestBundleLookupByNameAndID(t *testing.T) {
+	client := newBundleClient(t)
+	ctx := context.Background()
+
+	pokemon, err := client.GetPokemon(ctx, "pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon by name: %v", err)
+	}
+	if pokemon.ID != 25 || pokemon.BaseExperience != 112 {
+		t.Errorf("GetPokemon() = %+v", pokemon)
+	}
+
+	area, err := client.GetLocationArea(ctx, "3")
+	if err != nil {
+		t.Fatalf("GetLocationArea by ID: %v", err)
+	}
+	if area.Name != "area-3" {
+		t.Errorf("GetLocationArea() = %+v", area)
+	}
+
+	_, err = client.GetPokemon(ctx, "missingno")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing Pokemon, got %v", err)
+	}
+}
+
+func TestBundlePagination(t *testing.T) {
+	client := newBundleClient(t)
+	ctx := context.Background()
+
+	page, err := client.ListLocationAreas(ctx, 2, 2)
+	if err != nil {
+		t.Fatalf("ListLocationAreas: %v", err)
+	}
+	if page.Count != 5 || len(page.Results) != 2 || page.Results[0].Name != "area-3" {
+		t.Errorf("unexpected page %+v", page)
+	}
+	if page.Next == nil || *page.Next != client.LocationAreaListURL(4, 2) {
+		t.Errorf("next = %v, expected %s", page.Next, client.LocationAreaListURL(4, 2))
+	}
+	if page.Previous == nil || *page.Previous != client.LocationAreaListURL(0, 2) {
+		t.Errorf("previous = %v, expected %s", page.Previous, client.LocationAreaListURL(0, 2))
+	}
+
+	last, err := client.LocationAreaPage(ctx, *page.Next)
+	if err != nil {
+		t.Fatalf("LocationAreaPage: %v", err)
+	}
+	if len(last.Results) != 1 || last.Next != nil {
+		t.Errorf("unexpected last page %+v", last)
+	}
+}
+
+func TestWriteBundleRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	err := WriteBundleIndex(dir, "location-area", []BundleItem{
+		{Name: "area-2", URL: "https://pokeapi.co/api/v2/location-area/2/"},
+		{Name: "area-1", URL: "https://pokeapi.co/api/v2/location-area/1/"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WriteBundleItem(dir, "pokemon", 25, "pikachu", []byte(`{"id":25,"name":"pikachu"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "api", "v2", "pokemon", "25", "index.json")); err != nil {
+		t.Errorf("expected the api-data layout: %v", err)
+	}
+
+	bundle, closer, err := OpenBundle(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer.Close()
+	client := NewClient(WithHTTPClient(&http.Client{Transport: bundle}))
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Errorf("GetPokemon from written bundle: %v", err)
+	}
+	page, err := client.ListLocationAreas(context.Background(), 0, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Results) != 2 || page.Results[0].Name != "area-1" {
+		t.Errorf("expected the index sorted by ID, got %+v", page.Results)
+	}
+
+	// Evolution chains have no names, they are told apart by ID
+	for _, id := range []int{10, 36} {
+		if err := WriteBundleItem(dir, "evolution-chain", id, "", []byte(`{"id":1}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "api", "v2", "evolution-chain", "index.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chains BundleList
+	if err := json.Unmarshal(data, &chains); err != nil {
+		t.Fatal(err)
+	}
+	if chains.Count != 2 {
+		t.Errorf("expected 2 evolution chains in the index, got %+v", chains.Results)
+	}
+}
@@ -0,0 +1,318 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokecache"
+)
+
+// This package wraps the PokeAPI endpoints used by the CLI.
+
+const (
+	DefaultBaseURL   = "https://pokeapi.co/api/v2"
+	DefaultUserAgent = "pokedex-cli"
+	DefaultTimeout   = 10 * time.Second // deadline for a single request attempt
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	cache      *pokecache.Cache // optional, nil disables caching
+	userAgent  string
+	timeout    time.Duration // per-attempt deadline, 0 disables it
+	ttl        func(url string) time.Duration
+	retry      RetryPolicy
+	limiter    *RateLimiter // optional, nil means no client-side rate limit
+	clock      Clock
+	jitter     func() float64
+
+	inflight flightGroup // makes concurrent requests for the same URL share one fetch
+}
+
+// Option configures a Client in NewClient.
+type Option func(*Client)
+
+// WithBaseURL points the client at another PokeAPI instance, e.g. a local mirror.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithCache(cache *pokecache.Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRequestTimeout sets the deadline for every single request attempt.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithTTLPolicy replaces the hook that decides how long a response stays cached.
+func WithTTLPolicy(ttl func(url string) time.Duration) Option {
+	return func(c *Client) {
+		c.ttl = ttl
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithRateLimiter throttles every request sent by the client.
+// The same limiter can be shared by several clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithClock replaces the time source used to wait between retries.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+func NewClient(opts ...Option) *Client {
+	client := &Client{
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{},
+		userAgent:  DefaultUserAgent,
+		timeout:    DefaultTimeout,
+		ttl:        DefaultTTL,
+		retry:      DefaultRetryPolicy,
+		clock:      realClock{},
+		jitter:     rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Cache returns the response cache, or nil if the client does not cache.
+func (c *Client) Cache() *pokecache.Cache {
+	return c.cache
+}
+
+// DefaultTTL keeps static resources like /pokemon/ and /location-area/{name} for days,
+// while paginated listings expire sooner.
+func DefaultTTL(url string) time.Duration {
+	if strings.Contains(url, "?") {
+		return time.Hour
+	}
+	for _, resource := range []string{"/pokemon/", "/pokemon-species/", "/location-area/", "/location/"} {
+		if strings.Contains(url, resource) {
+			return 7 * 24 * time.Hour
+		}
+	}
+	return 24 * time.Hour
+}
+
+// LocationAreaListURL builds the URL of a page of location areas.
+func (c *Client) LocationAreaListURL(offset, limit int) string {
+	return fmt.Sprintf("%s/location-area/?limit=%d&offset=%d", c.baseURL, limit, offset)
+}
+
+func (c *Client) ListLocationAreas(ctx context.Context, offset, limit int) (LocationAreaListResponse, error) {
+	return c.LocationAreaPage(ctx, c.LocationAreaListURL(offset, limit))
+}
+
+// LocationAreaPage fetches a page of location areas by URL,
+// as found in the Next and Previous fields of a previous page.
+func (c *Client) LocationAreaPage(ctx context.Context, pageURL string) (LocationAreaListResponse, error) {
+	var response LocationAreaListResponse
+	err := GetWithCache(ctx, c, pageURL, &response)
+	return response, err
+}
+
+// GetLocationArea fetches a location area by name or numeric ID.
+func (c *Client) GetLocationArea(ctx context.Context, nameOrID string) (LocationArea, error) {
+	var area LocationArea
+	err := GetWithCache(ctx, c, c.ResourceURL("location-area", nameOrID), &area)
+	return area, err
+}
+
+// GetLocation fetches a location, whose Areas can be fetched with GetLocationArea.
+func (c *Client) GetLocation(ctx context.Context, nameOrID string) (Location, error) {
+	var location Location
+	err := GetWithCache(ctx, c, c.ResourceURL("location", nameOrID), &location)
+	return location, err
+}
+
+// GetPokemonSpecies fetches a species by name or ID, as found in Pokemon.Species.
+func (c *Client) GetPokemonSpecies(ctx context.Context, nameOrID string) (PokemonSpecies, error) {
+	var species PokemonSpecies
+	err := GetWithCache(ctx, c, c.ResourceURL("pokemon-species", nameOrID), &species)
+	return species, err
+}
+
+// GetGrowthRate fetches a growth rate by name or ID, as found in PokemonSpecies.GrowthRate.
+func (c *Client) GetGrowthRate(ctx context.Context, nameOrID string) (GrowthRate, error) {
+	var rate GrowthRate
+	err := GetWithCache(ctx, c, c.ResourceURL("growth-rate", nameOrID), &rate)
+	return rate, err
+}
+
+// GetEvolutionChain fetches an evolution chain by ID, as found at the end of PokemonSpecies.EvolutionChain.URL.
+func (c *Client) GetEvolutionChain(ctx context.Context, id string) (EvolutionChain, error) {
+	var chain EvolutionChain
+	err := GetWithCache(ctx, c, c.ResourceURL("evolution-chain", id), &chain)
+	return chain, err
+}
+
+func (c *Client) GetVersion(ctx context.Context, name string) (Version, error) {
+	var version Version
+	err := GetWithCache(ctx, c, c.ResourceURL("version", name), &version)
+	return version, err
+}
+
+func (c *Client) GetPokemon(ctx context.Context, name string) (Pokemon, error) {
+	var pokemon Pokemon
+	err := GetWithCache(ctx, c, c.ResourceURL("pokemon", name), &pokemon)
+	return pokemon, err
+}
+
+// ResourceURL builds the URL of a single item, e.g. ResourceURL("pokemon", "pikachu").
+func (c *Client) ResourceURL(resource, nameOrID string) string {
+	return fmt.Sprintf("%s/%s/%s", c.baseURL, resource, url.PathEscape(nameOrID))
+}
+
+// GetWithCache decodes the JSON at url into target, serving it from the cache when possible.
+func GetWithCache[T any](ctx context.Context, c *Client, url string, target *T) error {
+	data, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		return fmt.Errorf("error decoding JSON: %w", err)
+	}
+	return nil
+}
+
+// get returns the raw body at url, from the cache or from the network.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	if c.cache != nil {
+		if cachedData, found := c.cache.Get(url); found {
+			return cachedData, nil
+		}
+	}
+
+	// Fetch from HTTP if not in cache, joining any request already in flight
+	return c.inflight.Do(ctx, url, func(ctx context.Context) ([]byte, error) {
+		body, err := c.fetch(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		if c.cache != nil {
+			c.cache.AddWithTTL(url, body, c.ttl(url))
+		}
+		return body, nil
+	})
+}
+
+// fetch requests url, retrying transient failures with jittered exponential backoff.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		body, err := c.fetchOnce(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+		// A canceled caller is never retried, a timed out attempt is
+		if ctx.Err() != nil || attempt >= c.retry.MaxAttempts || !retryable(err) {
+			return nil, err
+		}
+
+		delay := c.retry.backoff(attempt, c.jitter())
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+			// The server knows best, within the bound of the policy
+			delay = statusErr.RetryAfter
+			if c.retry.MaxDelay > 0 {
+				delay = min(delay, c.retry.MaxDelay)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.clock.After(delay):
+		}
+	}
+}
+
+func (c *Client) fetchOnce(ctx context.Context, url string) ([]byte, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building request for %s: %w", url, err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data from %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	// Error pages must never reach the cache
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &StatusError{
+			URL:        url,
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), c.clock.Now()),
+		}
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from %s: %w", url, err)
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("error decoding JSON: invalid response from %s", url)
+	}
+	return body, nil
+}
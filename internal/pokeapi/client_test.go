@@ -0,0 +1,235 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokecache"
+)
+
+func TestDefaultTTL(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected time.Duration
+	}{
+		{url: "https://pokeapi.co/api/v2/location-area/?limit=20&offset=0", expected: time.Hour},
+		{url: "https://pokeapi.co/api/v2/location-area/canalave-city-area", expected: 7 * 24 * time.Hour},
+		{url: "https://pokeapi.co/api/v2/pokemon/pikachu", expected: 7 * 24 * time.Hour},
+		{url: "https://pokeapi.co/api/v2/berry/cheri", expected: 24 * time.Hour},
+	}
+
+	for _, c := range cases {
+		if actual := DefaultTTL(c.url); actual != c.expected {
+			t.Errorf("DefaultTTL(%q) = %v, expected %v", c.url, actual, c.expected)
+		}
+	}
+}
+
+func TestGetPokemon(t *testing.T) {
+	var userAgent, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		path = r.URL.Path
+		fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL+"/api/v2/"), WithUserAgent("pokedex-test"))
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if pokemon.Name != "pikachu" || pokemon.BaseExperience != 112 {
+		t.Errorf("GetPokemon() = %+v", pokemon)
+	}
+	if path != "/api/v2/pokemon/pikachu" {
+		t.Errorf("requested %q, expected /api/v2/pokemon/pikachu", path)
+	}
+	if userAgent != "pokedex-test" {
+		t.Errorf("sent user agent %q, expected pokedex-test", userAgent)
+	}
+}
+
+func TestGetWithCacheSharesInFlightRequests(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release // hold the response until every caller is waiting
+		fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(WithBaseURL(server.URL), WithCache(cache))
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make([]Pokemon, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = client.GetPokemon(context.Background(), "pikachu")
+		}()
+	}
+	// Give the callers time to pile up behind the first request
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, server saw %d", n)
+	}
+	for i := range callers {
+		if errs[i] != nil {
+			t.Errorf("caller %d: unexpected error %v", i, errs[i])
+		}
+		if results[i].Name != "pikachu" || results[i].BaseExperience != 112 {
+			t.Errorf("caller %d: got %+v", i, results[i])
+		}
+	}
+
+	// Later calls are served from the cache
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected the cache to answer, server saw %d requests", n)
+	}
+}
+
+func TestSharedFetchOutlivesCanceledCaller(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.GetPokemon(ctx, "pikachu")
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	second := make(chan error, 1)
+	var pokemon Pokemon
+	go func() {
+		var err error
+		pokemon, err = client.GetPokemon(context.Background(), "pikachu")
+		second <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// The first caller gives up, the second still waits for the same fetch
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller: expected context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Fatalf("second caller: unexpected error %v", err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("second caller: got %+v", pokemon)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, server saw %d", n)
+	}
+}
+
+func TestStatusErrors(t *testing.T) {
+	cases := []struct {
+		status   int
+		expected error
+	}{
+		{status: http.StatusNotFound, expected: ErrNotFound},
+		{status: http.StatusTooManyRequests, expected: ErrRateLimited},
+		{status: http.StatusInternalServerError, expected: ErrServerError},
+		{status: http.StatusBadGateway, expected: ErrServerError},
+	}
+
+	for _, c := range cases {
+		t.Run(http.StatusText(c.status), func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(c.status)
+				fmt.Fprint(w, `{"detail":"nope"}`)
+			}))
+			defer server.Close()
+
+			cache := pokecache.NewCache(time.Minute)
+			defer cache.Close()
+			client := NewClient(WithBaseURL(server.URL), WithCache(cache), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+			_, err := client.GetPokemon(context.Background(), "notapokemon")
+			if !errors.Is(err, c.expected) {
+				t.Errorf("expected errors.Is(err, %v), got %v", c.expected, err)
+			}
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != c.status {
+				t.Errorf("expected a *StatusError with code %d, got %v", c.status, err)
+			}
+
+			// A second call must go back to the server instead of reading a cached error page
+			client.GetPokemon(context.Background(), "notapokemon")
+			if n := requests.Load(); n != 2 {
+				t.Errorf("expected 2 requests, server saw %d", n)
+			}
+		})
+	}
+}
+
+func TestGetPokemonCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release // never answer while the test is running
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := client.GetPokemon(ctx, "pikachu")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRequestTimeoutIsRetried(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			<-r.Context().Done() // hang until the client gives up on this attempt
+			return
+		}
+		fmt.Fprint(w, `{"name":"pikachu"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRequestTimeout(20*time.Millisecond), WithClock(newFakeClock()))
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if pokemon.Name != "pikachu" || requests.Load() != 2 {
+		t.Errorf("expected the second attempt to succeed, got %+v after %d requests", pokemon, requests.Load())
+	}
+}
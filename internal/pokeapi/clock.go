@@ -0,0 +1,20 @@
+package pokeapi
+
+import "time"
+
+// Clock is the time source used for backoff and rate limiting,
+// so tests can swap in a fake one instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,137 @@
+package pokeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fixtures let tests run against recorded PokeAPI traffic instead of the network:
+// RecordingTransport saves every request/response pair to a directory and
+// ReplayTransport serves them back.
+
+// Fixture is one recorded request/response pair, stored as <name>.json.
+type Fixture struct {
+	Method     string          `json:"method"`
+	URL        string          `json:"url"`
+	StatusCode int             `json:"status"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`      // JSON bodies, kept readable
+	BodyText   string          `json:"body_text,omitempty"` // any other body
+}
+
+// RecordingTransport forwards requests to Next (http.DefaultTransport if nil)
+// and writes each response to Dir as a fixture.
+type RecordingTransport struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	fixture := Fixture{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: res.StatusCode,
+		Header:     http.Header{"Content-Type": res.Header.Values("Content-Type")},
+	}
+	if json.Valid(body) {
+		fixture.Body = body
+	} else {
+		fixture.BodyText = string(body)
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(t.Dir, FixtureName(req)+".json"), data, 0o644); err != nil {
+		return nil, fmt.Errorf("error writing fixture: %w", err)
+	}
+	return res, nil
+}
+
+// ReplayTransport answers requests from fixtures in Dir and never touches the network.
+// A request without a fixture fails, so missing recordings are easy to spot.
+type ReplayTransport struct {
+	Dir string
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	name := FixtureName(req)
+	data, err := os.ReadFile(filepath.Join(t.Dir, name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("no fixture %s.json for %s %s: %w", name, req.Method, req.URL, err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("error decoding fixture %s.json: %w", name, err)
+	}
+
+	body := []byte(fixture.Body)
+	if fixture.BodyText != "" {
+		body = []byte(fixture.BodyText)
+	}
+	header := fixture.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.StatusCode, http.StatusText(fixture.StatusCode)),
+		StatusCode:    fixture.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// FixtureName derives a file name from the request method, path and query,
+// e.g. "get_location-area_limit-20_offset-0". The host is ignored so recordings
+// work for any base URL.
+func FixtureName(req *http.Request) string {
+	target := strings.Trim(req.URL.Path, "/")
+	target = strings.TrimPrefix(target, bundleAPIPrefix+"/")
+	if req.URL.RawQuery != "" {
+		target += "_" + req.URL.RawQuery
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		case r == '=':
+			return '-'
+		}
+		return '_'
+	}, target)
+	return strings.ToLower(req.Method) + "_" + name
+}
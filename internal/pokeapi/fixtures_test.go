@@ -0,0 +1,63 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/pokemon/pikachu" {
+			fmt.Fprint(w, `{"name":"pikachu","base_experience":112}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+
+	recorder := NewClient(
+		WithBaseURL(server.URL+"/api/v2"),
+		WithHTTPClient(&http.Client{Transport: &RecordingTransport{Dir: dir}}),
+	)
+	if _, err := recorder.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+	recorder.GetPokemon(context.Background(), "notapokemon")
+	server.Close() // replay must not need the server
+
+	replayer := NewClient(WithHTTPClient(&http.Client{Transport: &ReplayTransport{Dir: dir}}))
+	pokemon, err := replayer.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	if pokemon.Name != "pikachu" || pokemon.BaseExperience != 112 {
+		t.Errorf("replayed %+v", pokemon)
+	}
+	if _, err := replayer.GetPokemon(context.Background(), "notapokemon"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the recorded 404 to replay as ErrNotFound, got %v", err)
+	}
+	if _, err := replayer.GetPokemon(context.Background(), "eevee"); err == nil {
+		t.Errorf("expected an error for a request without a fixture")
+	}
+}
+
+func TestFixtureName(t *testing.T) {
+	cases := map[string]string{
+		"https://pokeapi.co/api/v2/pokemon/pikachu":                  "get_pokemon_pikachu",
+		"https://pokeapi.co/api/v2/location-area/?limit=20&offset=0": "get_location-area_limit-20_offset-0",
+		"http://localhost:8080/api/v2/pokemon/Mr.Mime/":              "get_pokemon_mr_mime",
+	}
+	for rawURL, expected := range cases {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := FixtureName(req); actual != expected {
+			t.Errorf("FixtureName(%q) = %q, expected %q", rawURL, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent calls that share a key:
+// the first caller starts fn and everyone arriving while it runs gets the same result.
+// fn runs under a context detached from the callers, cancelled once every caller has stopped waiting.
+type flightGroup struct {
+	mux   sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{} // closed once val and err are set
+	val     []byte
+	err     error
+	waiters int                // callers still waiting, guarded by flightGroup.mux
+	cancel  context.CancelFunc // stops fn when no caller waits anymore
+}
+
+func (g *flightGroup) Do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mux.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		fnCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			call.val, call.err = fn(fnCtx)
+			cancel()
+			g.mux.Lock()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mux.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mux.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		g.mux.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody wants the result anymore, later callers start a fresh fetch
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mux.Unlock()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,69 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every goroutine using the same Client.
+type RateLimiter struct {
+	mux    sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket size
+	tokens float64 // may go negative, which means callers are queued
+	last   time.Time
+	clock  Clock
+}
+
+// NewRateLimiter allows rate requests per second on average, with bursts of up to burst requests.
+// A nil clock uses the real time.
+func NewRateLimiter(rate float64, burst int, clock Clock) *RateLimiter {
+	if clock == nil {
+		clock = realClock{}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+		clock:  clock,
+	}
+}
+
+// Wait blocks until the caller may send a request or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.clock.After(delay):
+		return nil
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it.
+// Taking the token up front keeps concurrent callers in line without holding the lock while sleeping.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	now := l.clock.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
@@ -0,0 +1,228 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeClock advances instantly whenever someone waits on it and records the waits.
+type fakeClock struct {
+	mux    sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"name":"pikachu"}`)
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+	client := NewClient(WithBaseURL(server.URL), WithClock(clock), WithRetryPolicy(policy))
+
+	pokemon, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if pokemon.Name != "pikachu" {
+		t.Errorf("GetPokemon() = %+v", pokemon)
+	}
+	if len(clock.sleeps) != 2 {
+		t.Fatalf("expected 2 backoff sleeps, got %v", clock.sleeps)
+	}
+	// Jitter spreads each delay over [d/2, d)
+	for i, max := range []time.Duration{time.Second, 2 * time.Second} {
+		if clock.sleeps[i] < max/2 || clock.sleeps[i] >= max {
+			t.Errorf("sleep %d = %v, expected within [%v, %v)", i, clock.sleeps[i], max/2, max)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   RetryPolicy
+		attempt  int
+		expected time.Duration
+	}{
+		{name: "first", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, attempt: 1, expected: time.Second},
+		{name: "doubled", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, attempt: 4, expected: 8 * time.Second},
+		{name: "capped", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, attempt: 4, expected: 5 * time.Second},
+		{name: "no bound", policy: RetryPolicy{BaseDelay: time.Second}, attempt: 4, expected: 8 * time.Second},
+	}
+	for _, c := range cases {
+		// Without jitter the delay is the lower end, d/2
+		if actual := c.policy.backoff(c.attempt, 0); actual != c.expected/2 {
+			t.Errorf("%s: backoff(%d) = %v, expected %v", c.name, c.attempt, actual, c.expected/2)
+		}
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"name":"pikachu"}`)
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	client := NewClient(WithBaseURL(server.URL), WithClock(clock))
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != 7*time.Second {
+		t.Errorf("expected a single 7s wait, got %v", clock.sleeps)
+	}
+}
+
+func TestRetryAfterIsBounded(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"name":"pikachu"}`)
+	}))
+	defer server.Close()
+
+	clock := newFakeClock()
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	client := NewClient(WithBaseURL(server.URL), WithClock(clock), WithRetryPolicy(policy))
+
+	if _, err := client.GetPokemon(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("GetPokemon: %v", err)
+	}
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != 10*time.Second {
+		t.Errorf("expected a single wait of MaxDelay, got %v", clock.sleeps)
+	}
+}
+
+func TestNoRetryOnNotFound(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithClock(newFakeClock()))
+	client.GetPokemon(context.Background(), "notapokemon")
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, server saw %d", n)
+	}
+}
+
+// failingTransport fails every request with err and counts the attempts.
+type failingTransport struct {
+	err      error
+	requests atomic.Int32
+}
+
+func (t *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return nil, t.err
+}
+
+func TestRetryOnlyNetworkErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected int32
+	}{
+		{name: "connection refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, expected: 3},
+		{name: "connection reset", err: syscall.ECONNRESET, expected: 3},
+		// e.g. a replay transport without a recorded response
+		{name: "other transport error", err: errors.New("no fixture for this request"), expected: 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			transport := &failingTransport{err: c.err}
+			client := NewClient(WithHTTPClient(&http.Client{Transport: transport}), WithClock(newFakeClock()))
+			if _, err := client.GetPokemon(context.Background(), "pikachu"); err == nil {
+				t.Fatal("expected an error")
+			}
+			if n := transport.requests.Load(); n != c.expected {
+				t.Errorf("expected %d attempts, got %d", c.expected, n)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{header: "", expected: 0},
+		{header: "3", expected: 3 * time.Second},
+		{header: "Mon, 01 Jan 2024 00:00:30 GMT", expected: 30 * time.Second},
+		{header: "soon", expected: 0},
+	}
+
+	for _, c := range cases {
+		if actual := parseRetryAfter(c.header, now); actual != c.expected {
+			t.Errorf("parseRetryAfter(%q) = %v, expected %v", c.header, actual, c.expected)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewRateLimiter(1, 2, clock)
+
+	for range 4 {
+		limiter.Wait(context.Background())
+	}
+
+	// The burst covers the first two requests, then one per second
+	expected := []time.Duration{time.Second, time.Second}
+	if fmt.Sprint(clock.sleeps) != fmt.Sprint(expected) {
+		t.Errorf("sleeps = %v, expected %v", clock.sleeps, expected)
+	}
+}
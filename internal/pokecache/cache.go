@@ -1,6 +1,7 @@
 package pokecache
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
@@ -8,53 +9,228 @@ import (
 // This package will be responsible for all of our caching logic.
 
 type Cache struct {
-	entries map[string]cacheEntry
-	mux     *sync.RWMutex // Changed to RWMutex for better read performance
+	entries map[string]*list.Element // values are *cacheEntry
+	lru     *list.List               // most recently used at the front
+	mux     *sync.RWMutex            // Get takes the write lock to reorder lru, only Stats and Entries read-lock
+	disk    *DiskStore               // optional second tier, nil when the cache is memory only
+
+	defaultTTL time.Duration // used by Add, also the reap interval
+	maxEntries int           // 0 means no limit
+	maxBytes   int           // 0 means no limit
+	bytes      int           // current size of keys and values held in memory
+
+	stats Stats // counters only, Entries and Bytes are filled in by Stats(), guarded by mux
+
+	closed  bool          // set by Close, guarded by mux
+	done    chan struct{} // closed to stop the reaper
+	stopped chan struct{} // closed by the reaper once it has returned
+	once    sync.Once
+}
+
+// Option configures a Cache in NewCache.
+type Option func(*Cache)
+
+// WithMaxEntries caps the number of entries kept in memory.
+// The least recently used entries are evicted first.
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMaxBytes caps the memory used by keys and values.
+// The least recently used entries are evicted first.
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithDisk adds an on-disk tier that Get falls through to after a memory miss.
+func WithDisk(disk *DiskStore) Option {
+	return func(c *Cache) {
+		c.disk = disk
+	}
 }
 
 type cacheEntry struct {
+	key       string
 	createdAt time.Time
+	expiresAt time.Time
 	val       []byte
 }
 
-func NewCache(interval time.Duration) *Cache {
+func (e *cacheEntry) expired(now time.Time) bool {
+	return now.After(e.expiresAt)
+}
+
+func (e *cacheEntry) size() int {
+	return len(e.key) + len(e.val)
+}
+
+func NewCache(interval time.Duration, opts ...Option) *Cache {
 	var cache = &Cache{
-		entries: make(map[string]cacheEntry),
-		mux:     &sync.RWMutex{},
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		mux:        &sync.RWMutex{},
+		defaultTTL: interval,
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(cache)
 	}
 	go cache.reapLoop(interval)
 	return cache
 }
 
+// Close stops the reaper goroutine and releases all entries held in memory.
+// After Close, Add is a no-op and Get always misses. Close is safe to call more than once.
+func (c *Cache) Close() {
+	c.once.Do(func() {
+		close(c.done)
+		<-c.stopped
+
+		c.mux.Lock()
+		defer c.mux.Unlock()
+		c.closed = true
+		c.entries = make(map[string]*list.Element)
+		c.lru.Init()
+		c.bytes = 0
+	})
+}
+
+// Add stores val under key using the cache's default TTL (the interval given to NewCache).
+// The disk tier, if any, keeps the entry for its own TTL.
 func (c *Cache) Add(key string, val []byte) {
+	if !c.addMemory(key, val, time.Now().Add(c.defaultTTL)) {
+		return
+	}
+	if c.disk != nil {
+		// The disk tier is best effort, a failed write only costs a refetch later
+		c.disk.Put(key, val, 0)
+	}
+}
+
+// AddWithTTL stores val under key for ttl, in memory and on disk.
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	if !c.addMemory(key, val, time.Now().Add(ttl)) {
+		return
+	}
+	if c.disk != nil {
+		c.disk.Put(key, val, ttl)
+	}
+}
+
+// addMemory stores an entry in memory and reports false if the cache is closed.
+func (c *Cache) addMemory(key string, val []byte, expiresAt time.Time) bool {
 	c.mux.Lock()
 	defer c.mux.Unlock()
-	c.entries[key] = cacheEntry{
+	if c.closed {
+		return false
+	}
+	if elem, exists := c.entries[key]; exists {
+		c.removeElement(elem)
+	}
+	entry := &cacheEntry{
+		key:       key,
 		createdAt: time.Now(),
+		expiresAt: expiresAt,
 		val:       val,
 	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.bytes += entry.size()
+	c.evict()
+	return true
+}
+
+// evict drops least recently used entries until the cache fits its limits.
+// The caller must hold the write lock.
+func (c *Cache) evict() {
+	for c.lru.Len() > 0 {
+		overEntries := c.maxEntries > 0 && c.lru.Len() > c.maxEntries
+		overBytes := c.maxBytes > 0 && c.bytes > c.maxBytes
+		if !overEntries && !overBytes {
+			return
+		}
+		c.removeElement(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// removeElement unlinks an entry from both the map and the LRU list.
+// The caller must hold the write lock.
+func (c *Cache) removeElement(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size()
 }
 
 func (c *Cache) Get(key string) ([]byte, bool) {
-	c.mux.RLock()
-	defer c.mux.RUnlock()
-	entry, exists := c.entries[key]
-	if !exists {
+	// Get needs the write lock because a hit moves the entry to the front of the LRU list
+	c.mux.Lock()
+	closed := c.closed
+	elem, exists := c.entries[key]
+	if exists && elem.Value.(*cacheEntry).expired(time.Now()) {
+		// Expired entries are dropped right away instead of waiting for the reaper
+		c.removeElement(elem)
+		c.stats.Expirations++
+		exists = false
+	}
+	if exists {
+		c.lru.MoveToFront(elem)
+		c.stats.Hits++
+	}
+	c.mux.Unlock()
+	if exists {
+		return elem.Value.(*cacheEntry).val, true
+	}
+
+	if closed || c.disk == nil {
+		c.recordMiss()
+		return nil, false
+	}
+	entry, found := c.disk.get(key)
+	if !found {
+		c.recordMiss()
 		return nil, false
 	}
+	c.mux.Lock()
+	c.stats.Hits++
+	c.stats.DiskHits++
+	c.mux.Unlock()
+	// Promote the entry so the next lookup is served from memory
+	expiresAt := entry.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(c.defaultTTL)
+	}
+	c.addMemory(key, entry.Val, expiresAt)
+	return entry.Val, true
+}
 
-	return entry.val, true
+func (c *Cache) recordMiss() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.stats.Misses++
 }
 
 func (c *Cache) reapLoop(interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop() // prevent ticker leak
+	defer close(c.stopped)
 
-	for range ticker.C {
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
 		c.mux.Lock()
-		for k, entry := range c.entries {
-			if time.Since(entry.createdAt) > interval {
-				delete(c.entries, k)
+		now := time.Now()
+		for _, elem := range c.entries {
+			if elem.Value.(*cacheEntry).expired(now) {
+				c.removeElement(elem)
+				c.stats.Expirations++
 			}
 		}
 		c.mux.Unlock()
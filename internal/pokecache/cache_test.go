@@ -2,6 +2,7 @@ package pokecache
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -29,6 +30,7 @@ func TestAddGet(t *testing.T) {
 	for i, c := range cases {
 		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
 			cache := NewCache(interval)
+			defer cache.Close()
 			cache.Add(c.key, c.val)
 			val, ok := cache.Get(c.key)
 			if !ok {
@@ -47,6 +49,7 @@ func TestReapLoop(t *testing.T) {
 	const baseTime = 5 * time.Millisecond
 	const waitTime = baseTime + 5*time.Millisecond
 	cache := NewCache(baseTime)
+	defer cache.Close()
 	cache.Add("https://example.com", []byte("testdata"))
 
 	_, ok := cache.Get("https://example.com")
@@ -63,3 +66,107 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 }
+
+func TestLRUEvictionByCount(t *testing.T) {
+	cache := NewCache(5*time.Second, WithMaxEntries(2))
+	defer cache.Close()
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+
+	// Touch "a" so that "b" becomes the least recently used entry
+	cache.Get("a")
+	cache.Add("c", []byte("3"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to still be cached", key)
+		}
+	}
+}
+
+func TestLRUEvictionByBytes(t *testing.T) {
+	// Each entry below is 1 byte of key plus 4 bytes of value
+	cache := NewCache(5*time.Second, WithMaxBytes(10))
+	defer cache.Close()
+	cache.Add("a", []byte("aaaa"))
+	cache.Add("b", []byte("bbbb"))
+	cache.Add("c", []byte("cccc"))
+
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected a to be evicted")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Errorf("expected c to still be cached")
+	}
+
+	// Replacing a key must not count its old value twice: b (5) + c (3) + d (2) fits in 10
+	cache.Add("c", []byte("cc"))
+	cache.Add("d", []byte("d"))
+	if _, ok := cache.Get("b"); !ok {
+		t.Errorf("expected b to fit alongside the smaller entries")
+	}
+}
+
+func TestLRUConcurrentAccess(t *testing.T) {
+	cache := NewCache(5*time.Second, WithMaxEntries(8))
+	defer cache.Close()
+	done := make(chan struct{})
+	for g := range 4 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for i := range 200 {
+				key := fmt.Sprintf("key-%d", (g*7+i)%16)
+				cache.Add(key, []byte("v"))
+				cache.Get(key)
+			}
+		}()
+	}
+	for range 4 {
+		<-done
+	}
+}
+
+func TestClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	cache := NewCache(time.Millisecond)
+	cache.Add("https://example.com", []byte("testdata"))
+
+	cache.Close()
+	cache.Close() // closing twice must be safe
+
+	if _, ok := cache.Get("https://example.com"); ok {
+		t.Errorf("expected entries to be released on Close")
+	}
+	cache.Add("https://example.com/path", []byte("moretestdata"))
+	if _, ok := cache.Get("https://example.com/path"); ok {
+		t.Errorf("expected Add after Close to be a no-op")
+	}
+	// The reaper may still be unwinding its defers right after Close returns
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected the reaper goroutine to exit, goroutines went from %d to %d", before, after)
+	}
+}
+
+func TestAddWithTTL(t *testing.T) {
+	// A long reap interval proves Get checks expiry itself
+	cache := NewCache(time.Hour)
+	defer cache.Close()
+	cache.AddWithTTL("short", []byte("testdata"), 5*time.Millisecond)
+	cache.AddWithTTL("long", []byte("testdata"), time.Hour)
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected short-lived entry to expire")
+	}
+	if _, ok := cache.Get("long"); !ok {
+		t.Errorf("expected long-lived entry to still be cached")
+	}
+}
@@ -0,0 +1,200 @@
+package pokecache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiskStore is the optional on-disk tier behind the in-memory cache.
+// Every key is stored in its own file named after the hash of the key.
+type DiskStore struct {
+	dir      string
+	ttl      time.Duration // default lifetime of an entry on disk, 0 means no expiry
+	maxBytes int64         // size cap for the whole directory, 0 means unlimited
+	mux      sync.Mutex    // serializes writes and evictions
+}
+
+type diskEntry struct {
+	Key       string
+	CreatedAt time.Time
+	ExpiresAt time.Time // zero means the entry never expires
+	Val       []byte
+}
+
+const diskEntryExt = ".entry"
+
+func NewDiskStore(dir string, ttl time.Duration, maxBytes int64) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskStore{
+		dir:      dir,
+		ttl:      ttl,
+		maxBytes: maxBytes,
+	}, nil
+}
+
+func (d *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+diskEntryExt)
+}
+
+func (d *DiskStore) Get(key string) ([]byte, bool) {
+	entry, ok := d.get(key)
+	if !ok {
+		return nil, false
+	}
+	return entry.Val, true
+}
+
+func (d *DiskStore) get(key string) (diskEntry, bool) {
+	entry, ok := d.read(d.path(key))
+	if !ok || entry.Key != key {
+		return diskEntry{}, false
+	}
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		d.Delete(key)
+		return diskEntry{}, false
+	}
+	return entry, true
+}
+
+func (d *DiskStore) read(path string) (diskEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return diskEntry{}, false
+	}
+	var entry diskEntry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entry); err != nil {
+		// A torn or foreign file is treated as a miss and cleaned up
+		os.Remove(path)
+		return diskEntry{}, false
+	}
+	return entry, true
+}
+
+// Put stores val under key for ttl, or for the store's own TTL when ttl <= 0.
+func (d *DiskStore) Put(key string, val []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = d.ttl
+	}
+	now := time.Now()
+	entry := diskEntry{
+		Key:       key,
+		CreatedAt: now,
+		Val:       val,
+	}
+	if ttl > 0 {
+		entry.ExpiresAt = now.Add(ttl)
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(entry)
+	if err != nil {
+		return err
+	}
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	// Write to a temp file and rename so readers never see a partial entry
+	tmp, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		return err
+	}
+
+	d.enforceCap()
+	return nil
+}
+
+func (d *DiskStore) Delete(key string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	os.Remove(d.path(key))
+}
+
+// DeletePrefix removes every entry whose key starts with prefix.
+func (d *DiskStore) DeletePrefix(prefix string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, de := range dirEntries {
+		if !strings.HasSuffix(de.Name(), diskEntryExt) {
+			continue
+		}
+		path := filepath.Join(d.dir, de.Name())
+		// Keys are only stored inside the files, so each one has to be decoded
+		if entry, ok := d.read(path); ok && strings.HasPrefix(entry.Key, prefix) {
+			os.Remove(path)
+		}
+	}
+}
+
+// enforceCap removes the oldest entries until the directory fits in maxBytes.
+// The caller must hold d.mux.
+func (d *DiskStore) enforceCap() {
+	if d.maxBytes <= 0 {
+		return
+	}
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+
+	type fileInfo struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []fileInfo
+	var total int64
+	for _, de := range dirEntries {
+		if !strings.HasSuffix(de.Name(), diskEntryExt) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, fileInfo{
+			path:    filepath.Join(d.dir, de.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, f := range files {
+		if total <= d.maxBytes {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package pokecache
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDiskSurvivesNewCache(t *testing.T) {
+	dir := t.TempDir()
+	disk, err := NewDiskStore(dir, time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := NewCache(5*time.Second, WithDisk(disk))
+	defer first.Close()
+	first.Add("https://example.com", []byte("testdata"))
+
+	// A fresh cache starts with empty memory but shares the directory
+	second := NewCache(5*time.Second, WithDisk(disk))
+	defer second.Close()
+	val, ok := second.Get("https://example.com")
+	if !ok {
+		t.Fatalf("expected to find key on disk")
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected %q, got %q", "testdata", val)
+	}
+}
+
+func TestDiskFallThroughAfterReap(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	disk, err := NewDiskStore(t.TempDir(), time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewCache(baseTime, WithDisk(disk))
+	defer cache.Close()
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(baseTime + 5*time.Millisecond)
+
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Errorf("expected the disk tier to answer after the memory entry was reaped")
+	}
+}
+
+func TestDiskTTL(t *testing.T) {
+	disk, err := NewDiskStore(t.TempDir(), 5*time.Millisecond, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := disk.Put("https://example.com", []byte("testdata"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := disk.Get("https://example.com"); ok {
+		t.Errorf("expected expired entry to be gone")
+	}
+}
+
+func TestDiskSizeCap(t *testing.T) {
+	dir := t.TempDir()
+	val := make([]byte, 1024)
+	// Enough room for roughly two entries including the gob header
+	disk, err := NewDiskStore(dir, time.Hour, 2*1024+512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 5 {
+		if err := disk.Put(fmt.Sprintf("key-%d", i), val, 0); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond) // keep modification times ordered
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) > 2 {
+		t.Errorf("expected at most 2 entries under the cap, found %d", len(entries))
+	}
+	if _, ok := disk.Get("key-4"); !ok {
+		t.Errorf("expected the newest entry to survive")
+	}
+	if _, ok := disk.Get("key-0"); ok {
+		t.Errorf("expected the oldest entry to be evicted")
+	}
+}
@@ -0,0 +1,77 @@
+package pokecache
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Stats is a snapshot of the cache counters.
+type Stats struct {
+	Hits        uint64 // lookups answered from memory or disk
+	DiskHits    uint64 // the subset of Hits that came from the disk tier
+	Misses      uint64
+	Evictions   uint64 // entries dropped to stay under the size limits
+	Expirations uint64 // entries dropped because their TTL ran out
+	Entries     int    // entries currently held in memory
+	Bytes       int    // size of the keys and values currently held in memory
+}
+
+// EntryInfo describes a single in-memory entry, as returned by Entries.
+type EntryInfo struct {
+	Key       string
+	Size      int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (c *Cache) Stats() Stats {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	stats.Bytes = c.bytes
+	return stats
+}
+
+// Entries lists the in-memory entries whose key starts with prefix, sorted by key.
+func (c *Cache) Entries(prefix string) []EntryInfo {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	var infos []EntryInfo
+	for key, elem := range c.entries {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		entry := elem.Value.(*cacheEntry)
+		infos = append(infos, EntryInfo{
+			Key:       key,
+			Size:      entry.size(),
+			CreatedAt: entry.createdAt,
+			ExpiresAt: entry.expiresAt,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Key < infos[j].Key
+	})
+	return infos
+}
+
+// ClearPrefix removes every entry whose key starts with prefix, from memory and disk,
+// and returns how many in-memory entries were removed. An empty prefix clears everything.
+func (c *Cache) ClearPrefix(prefix string) int {
+	c.mux.Lock()
+	removed := 0
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.removeElement(elem)
+			removed++
+		}
+	}
+	c.mux.Unlock()
+
+	if c.disk != nil {
+		c.disk.DeletePrefix(prefix)
+	}
+	return removed
+}
@@ -0,0 +1,51 @@
+package pokecache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	cache := NewCache(time.Hour, WithMaxEntries(2))
+	defer cache.Close()
+
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+	cache.Add("c", []byte("3")) // evicts a
+	cache.AddWithTTL("d", []byte("4"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	cache.Get("c") // hit
+	cache.Get("a") // miss
+	cache.Get("d") // expired, then a miss
+
+	stats := cache.Stats()
+	expected := Stats{Hits: 1, Misses: 2, Evictions: 2, Expirations: 1, Entries: 1, Bytes: 2}
+	if stats != expected {
+		t.Errorf("Stats() = %+v, expected %+v", stats, expected)
+	}
+}
+
+func TestClearPrefix(t *testing.T) {
+	disk, err := NewDiskStore(t.TempDir(), time.Hour, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewCache(time.Hour, WithDisk(disk))
+	defer cache.Close()
+
+	cache.Add("https://example.com/pokemon/pikachu", []byte("pikachu"))
+	cache.Add("https://example.com/pokemon/eevee", []byte("eevee"))
+	cache.Add("https://example.com/location-area/1", []byte("area"))
+
+	if removed := cache.ClearPrefix("https://example.com/pokemon/"); removed != 2 {
+		t.Errorf("ClearPrefix() removed %d entries, expected 2", removed)
+	}
+	if _, ok := cache.Get("https://example.com/pokemon/pikachu"); ok {
+		t.Errorf("expected pikachu to be cleared from memory and disk")
+	}
+	entries := cache.Entries("")
+	if len(entries) != 1 || entries[0].Key != "https://example.com/location-area/1" {
+		t.Errorf("Entries() = %+v, expected only the location area", entries)
+	}
+}
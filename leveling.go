@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// maxLevel is the highest level a Pokemon can reach.
+const maxLevel = 100
+
+// calcStat computes a stat of a Pokemon at a level with the Gen III formula, leaving out EVs and natures.
+func calcStat(pokemon pokeapi.Pokemon, name string, iv, level int) int {
+	for _, stat := range pokemon.Stats {
+		if stat.Stat.Name != name {
+			continue
+		}
+		value := (2*stat.BaseStat + iv) * level / 100
+		if name == "hp" {
+			return value + level + 10
+		}
+		return value + 5
+	}
+	return 0
+}
+
+// Stat computes a stat of the caught Pokemon from its level and IVs.
+func (c CaughtPokemon) Stat(name string) int {
+	return calcStat(c.Pokemon, name, c.IVs[name], c.Level)
+}
+
+// experienceAt returns the total experience needed to reach level on rate.
+func experienceAt(rate pokeapi.GrowthRate, level int) int {
+	for _, l := range rate.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// defeatExperience is the experience for defeating or catching a wild Pokemon, as in Gen I to IV.
+func defeatExperience(baseExperience, level int) int {
+	return max(baseExperience*level/7, 1)
+}
+
+// growthRate fetches the growth rate of the species of pokemon.
+func growthRate(ctx context.Context, client *pokeapi.Client, pokemon pokeapi.Pokemon) (pokeapi.GrowthRate, error) {
+	species, err := client.GetPokemonSpecies(ctx, pokemon.Species.Name)
+	if err != nil {
+		return pokeapi.GrowthRate{}, fmt.Errorf("error fetching species data: %w", describeAPIError(err))
+	}
+	rate, err := client.GetGrowthRate(ctx, species.GrowthRate.Name)
+	if err != nil {
+		return pokeapi.GrowthRate{}, fmt.Errorf("error fetching growth rate: %w", describeAPIError(err))
+	}
+	return rate, nil
+}
+
+// partyLead returns the first party member able to battle, nil if they all fainted.
+func partyLead(config *Config) *CaughtPokemon {
+	for _, id := range config.Party {
+		if member := caughtByID(config.Caught, id); member.HP > 0 {
+			return member
+		}
+	}
+	return nil
+}
+
+// awardExperience gives the party lead the experience for defeating or catching
+// the wild pokemon at level, and evolves the lead if it leveled up enough.
+// Without a party nobody gains anything.
+func awardExperience(ctx context.Context, config *Config, pokemon pokeapi.Pokemon, level int) error {
+	lead := partyLead(config)
+	if lead == nil {
+		return nil
+	}
+	rate, err := growthRate(ctx, config.Client, lead.Pokemon)
+	if err != nil {
+		return err
+	}
+	levelBefore := lead.Level
+	gainExperience(lead, defeatExperience(pokemon.BaseExperience, level), rate, config.VersionGroup)
+	if lead.Level == levelBefore {
+		return nil
+	}
+
+	// Leveling up is when most Pokemon evolve
+	stages, err := nextEvolutions(ctx, config.Client, lead.Pokemon)
+	if err != nil {
+		return err
+	}
+	_, err = tryEvolve(ctx, config, lead, stages, evolutionTrigger{name: "level-up", now: time.Now()})
+	return err
+}
+
+// gainExperience adds exp to a caught Pokemon and levels it up along rate.
+// Pokemon caught before experience was recorded start from the experience of their level.
+func gainExperience(c *CaughtPokemon, exp int, rate pokeapi.GrowthRate, versionGroup string) {
+	c.Experience = max(c.Experience, experienceAt(rate, c.Level)) + exp
+	fmt.Printf("%s gained %d experience.\n", c.DisplayName(), exp)
+	for c.Level < maxLevel && c.Experience >= experienceAt(rate, c.Level+1) {
+		levelUp(c, versionGroup)
+	}
+}
+
+// levelUp raises the level of a caught Pokemon, recomputing its HP, and teaches it
+// the moves learned at the new level.
+func levelUp(c *CaughtPokemon, versionGroup string) {
+	c.Level++
+	updateMaxHP(c)
+	addFriendship(c, levelUpFriendship(c.Friendship))
+	fmt.Printf("%s grew to level %d!\n", c.DisplayName(), c.Level)
+	learnMoves(c, versionGroup)
+}
+
+// updateMaxHP recomputes the max HP after a level up or an evolution.
+// The HP gained comes on top of the current HP.
+func updateMaxHP(c *CaughtPokemon) {
+	oldMaxHP := c.MaxHP
+	c.MaxHP = c.Stat("hp")
+	c.HP = max(c.HP+c.MaxHP-oldMaxHP, 0)
+}
+
+// learnMoves teaches a caught Pokemon the moves it learns at its level.
+// With four moves known, it forgets the oldest.
+func learnMoves(c *CaughtPokemon, versionGroup string) {
+	for _, move := range levelUpMoves(c.Pokemon, movesVersionGroup(c.Pokemon, versionGroup)) {
+		if move.level != c.Level || slices.Contains(c.Moves, move.name) {
+			continue
+		}
+		if len(c.Moves) < 4 {
+			c.Moves = append(c.Moves, move.name)
+			fmt.Printf("%s learned %s!\n", c.DisplayName(), move.name)
+			continue
+		}
+		forgotten := c.Moves[0]
+		c.Moves = append(c.Moves[1:], move.name)
+		fmt.Printf("%s forgot %s and learned %s!\n", c.DisplayName(), forgotten, move.name)
+	}
+}
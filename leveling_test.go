@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCalcStat(t *testing.T) {
+	pikachu, err := newMockClient(t).GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Base HP 35 and speed 90
+	cases := []struct {
+		stat      string
+		iv, level int
+		expected  int
+	}{
+		{stat: "hp", iv: 0, level: 12, expected: 30},
+		{stat: "hp", iv: 31, level: 50, expected: 110},
+		{stat: "speed", iv: 31, level: 50, expected: 110},
+		{stat: "speed", iv: 0, level: 100, expected: 185},
+		{stat: "accuracy", iv: 0, level: 100, expected: 0},
+	}
+	for _, c := range cases {
+		if got := calcStat(pikachu, c.stat, c.iv, c.level); got != c.expected {
+			t.Errorf("calcStat(%s, iv %d, level %d) = %d, expected %d", c.stat, c.iv, c.level, got, c.expected)
+		}
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	client := newMockClient(t)
+	pikachu, err := client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rate, err := growthRate(context.Background(), client, pikachu)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pikachu grows on the medium curve, level n needs n^3 experience
+	caught := newCaughtPokemon(1, pikachu, 12, "", nil)
+	caught.HP = 20
+	output := captureOutput(t, func() {
+		gainExperience(&caught, 1100, rate, "")
+	})
+	if caught.Level != 14 || caught.Experience != 12*12*12+1100 {
+		t.Errorf("level %d with %d experience, expected level 14 with %d", caught.Level, caught.Experience, 12*12*12+1100)
+	}
+	if caught.Friendship != 10 {
+		t.Errorf("friendship = %d, expected 5 for each level", caught.Friendship)
+	}
+	if caught.MaxHP != 33 || caught.HP != 23 {
+		t.Errorf("HP %d/%d, expected the 3 HP gained on top of the current HP", caught.HP, caught.MaxHP)
+	}
+	// quick-attack comes at level 13 and replaces the oldest of four moves
+	if expected := []string{"growl", "tail-whip", "thunder-wave", "quick-attack"}; !slices.Equal(caught.Moves, expected) {
+		t.Errorf("moves = %v, expected %v", caught.Moves, expected)
+	}
+	for _, expected := range []string{"grew to level 13", "forgot thunder-shock and learned quick-attack", "grew to level 14"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	// Level 100 is the cap
+	caught.Level = 99
+	captureOutput(t, func() {
+		gainExperience(&caught, 5_000_000, rate, "")
+	})
+	if caught.Level != maxLevel {
+		t.Errorf("level %d, expected %d", caught.Level, maxLevel)
+	}
+}
@@ -2,17 +2,45 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"flag"
 	"fmt"
+	"math/rand/v2"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/AGX18/pokedex/internal/pokeapi"
 	"github.com/AGX18/pokedex/internal/pokecache"
 )
 
 var supportedCommands map[string]cliCommand
 
-var cache *pokecache.Cache = pokecache.NewCache(5 * time.Second)
+// newCache builds the response cache, backed by the user's cache dir when available.
+func newCache() *pokecache.Cache {
+	const (
+		reapInterval   = 5 * time.Second // also the TTL for entries added without one
+		memoryMaxBytes = 32 << 20
+		diskTTL        = 7 * 24 * time.Hour
+		diskMaxBytes   = 64 << 20
+	)
+	opts := []pokecache.Option{pokecache.WithMaxBytes(memoryMaxBytes)}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return pokecache.NewCache(reapInterval, opts...)
+	}
+	disk, err := pokecache.NewDiskStore(filepath.Join(dir, "pokedex"), diskTTL, diskMaxBytes)
+	if err != nil {
+		fmt.Println("Warning: disk cache disabled:", err)
+		return pokecache.NewCache(reapInterval, opts...)
+	}
+	opts = append(opts, pokecache.WithDisk(disk))
+	return pokecache.NewCache(reapInterval, opts...)
+}
 
 func init() {
 	supportedCommands = map[string]cliCommand{
@@ -28,24 +56,34 @@ func init() {
 		},
 		"map": {
 			name:        "map",
-			description: "Fetches the map of locations",
+			description: "Fetches the next page of locations (map [--limit <n>] [page <n> | goto <offset>])",
 			callback:    commandMap,
 		},
 		"mapb": {
 			name:        "mapb",
-			description: "Fetches the previous map of locations",
+			description: "Fetches the page before the one shown",
 			callback:    commandMapBack,
 		},
 		"explore": {
 			name:        "explore",
-			description: "Explore a specific location area by name",
+			description: "Explore a location area by name or ID, or every area of a location by its name (explore <area> [--version <v>] [--method <m>] [--sort rarity])",
 			callback:    commandExplore,
 		},
+		"encounter": {
+			name:        "encounter",
+			description: "Look for a wild Pokemon in the area explored last (encounter [--method <method>])",
+			callback:    commandEncounter,
+		},
 		"catch": {
 			name:        "catch",
-			description: "Catch a specific Pokemon by name",
+			description: "Catch the wild Pokemon met by the last encounter (catch [name] [--ball poke|great|ultra|master])",
 			callback:    commandCatch,
 		},
+		"bag": {
+			name:        "bag",
+			description: "List the balls left in your bag",
+			callback:    commandBag,
+		},
 		"inspect": {
 			name:        "inspect",
 			description: "Inspect a specific Pokemon by name",
@@ -56,21 +94,131 @@ func init() {
 			description: "Display all caught Pokemon",
 			callback:    commandPokedex,
 		},
+		"version": {
+			name:        "version",
+			description: "Show the game version (version), follow a game (version set <name>) or every game (version clear)",
+			callback:    commandVersion,
+		},
+		"seed": {
+			name:        "seed",
+			description: "Show the random seed of the session (seed) or replay from another one (seed <number>)",
+			callback:    commandSeed,
+		},
+		"battle": {
+			name:        "battle",
+			description: "Battle the wild Pokemon met by the last encounter with the lead of your party, who gains experience by winning",
+			callback:    commandBattle,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "Evolve a caught Pokemon by leveling up (evolve <name|id>), with an item from the bag (evolve <name|id> --item <item>) or by trading it (evolve <name|id> --trade)",
+			callback:    commandEvolve,
+		},
+		"party": {
+			name:        "party",
+			description: "Show your party (party), or change it (party add <name|id> [nickname], party remove <slot|name>, party swap <slot> <slot>, party heal)",
+			callback:    commandParty,
+		},
+		"sync": {
+			name:        "sync",
+			description: "Download location areas or locations (sync [area...]), Pokemon (sync pokemon <name>...) or game versions (sync version <name>...) into the offline bundle",
+			callback:    commandSync,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Show cache statistics (cache stats), list entries (cache list [prefix]) or purge them (cache clear [prefix])",
+			callback:    commandCache,
+		},
 	}
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "mockserver" {
+		runMockServer(os.Args[2:])
+		return
+	}
+
+	baseURL := flag.String("base-url", pokeapi.DefaultBaseURL, "PokeAPI base URL, e.g. a local mirror")
+	retries := flag.Int("retries", pokeapi.DefaultRetryPolicy.MaxAttempts-1, "how many times a failed request is retried")
+	timeout := flag.Duration("timeout", pokeapi.DefaultTimeout, "deadline for a single PokeAPI request")
+	rateLimit := flag.Float64("rate-limit", 10, "maximum PokeAPI requests per second, 0 disables the limit")
+	rateBurst := flag.Int("rate-burst", 5, "how many PokeAPI requests may go out at once before -rate-limit applies")
+	offline := flag.Bool("offline", false, "answer every request from the local bundle instead of PokeAPI")
+	bundleDir := flag.String("bundle", "", "directory or .zip archive in the api-data layout used by -offline and sync (default <config dir>/pokedex/bundle)")
+	seed := flag.Uint64("seed", 0, "seed for encounters and catches, to replay a session (default the saved seed, or a random one)")
+	flag.Parse()
+
+	if *bundleDir == "" {
+		dir, err := defaultBundleDir()
+		if err != nil && *offline {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		*bundleDir = dir
+	}
+
+	retryPolicy := pokeapi.DefaultRetryPolicy
+	retryPolicy.MaxAttempts = *retries + 1
+	clientOpts := []pokeapi.Option{
+		pokeapi.WithBaseURL(*baseURL),
+		pokeapi.WithCache(newCache()),
+		pokeapi.WithRetryPolicy(retryPolicy),
+		pokeapi.WithRequestTimeout(*timeout),
+	}
+	if *offline {
+		bundle, closer, err := pokeapi.OpenBundle(*bundleDir)
+		if err != nil {
+			fmt.Println("Error:", err)
+			fmt.Println("Run the Pokedex online and use the sync command to create a bundle.")
+			os.Exit(1)
+		}
+		defer closer.Close()
+		clientOpts = append(clientOpts, pokeapi.WithHTTPClient(&http.Client{Transport: bundle}))
+	} else if *rateLimit > 0 {
+		clientOpts = append(clientOpts, pokeapi.WithRateLimiter(pokeapi.NewRateLimiter(*rateLimit, *rateBurst, nil)))
+	}
+	client := pokeapi.NewClient(clientOpts...)
 	scanner := bufio.NewScanner(os.Stdin)
 	config := Config{
-		NextURL:     "https://pokeapi.co/api/v2/location-area/?limit=20&offset=0",
-		PrevURL:     "",
 		Offset:      0,  // Offset for pagination
 		Limit:       20, // Default limit for pagination
 		AreaName:    "", // For searching by area name
 		AreaID:      0,  // For searching by area ID
-		Pokedex:     make(map[string]Pokemon),
+		Seen:        make(map[string]bool),
+		Balls:       startingBalls(),
+		Items:       startingItems(),
 		PokemonName: "", // For catching a specific Pokemon
+		Client:      client,
+		Offline:     *offline,
+		BundleDir:   *bundleDir,
+	}
+
+	savePath, err := defaultSavePath()
+	if err != nil {
+		fmt.Println("Warning: progress will not be saved:", err)
+	}
+	config.SavePath = savePath
+	seedRNG(&config, rand.Uint64())
+	if err := loadProgress(config.SavePath, &config); err != nil {
+		fmt.Println("Error:", err)
+		fmt.Println("Move or delete the save file to start a new Pokedex.")
+		os.Exit(1)
 	}
+	// The save keeps caught Pokemon by name, their data comes from PokeAPI or the bundle
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err = loadCaughtPokemon(ctx, &config)
+	stop()
+	if err != nil {
+		fmt.Println("Error:", err)
+		fmt.Println("Your save file is fine, start the Pokedex again once PokeAPI can be reached, or sync your Pokemon to play offline.")
+		os.Exit(1)
+	}
+	// An explicit seed replays from the start instead of carrying on the saved sequence
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seedRNG(&config, *seed)
+		}
+	})
 	for {
 		fmt.Print("Pokedex > ")
 		scanner.Scan()
@@ -81,13 +229,24 @@ func main() {
 			fmt.Println("Unknown command")
 			continue
 		}
+		config.Args = words[1:]
 		if command.name == "explore" {
 			if len(words) < 2 {
 				fmt.Println("Please provide an area name or ID to explore.")
 				continue
 			}
-			config.AreaName = words[1] // Set the area name from the input
-		} else if command.name == "catch" || command.name == "inspect" {
+			// Numbers are area IDs, anything else an area or location name
+			if id, err := strconv.Atoi(words[1]); err == nil {
+				config.AreaName, config.AreaID = "", id
+			} else {
+				config.AreaName, config.AreaID = words[1], 0
+			}
+		} else if command.name == "catch" {
+			config.PokemonName = "" // catch the wild Pokemon unless one is named
+			if len(words) > 1 && !strings.HasPrefix(words[1], "--") {
+				config.PokemonName = words[1]
+			}
+		} else if command.name == "inspect" {
 			if len(words) < 2 {
 				fmt.Println("Please provide a Pokemon name.")
 				continue
@@ -95,11 +254,17 @@ func main() {
 			config.PokemonName = words[1] // Set the Pokemon name from the input
 		} else {
 			config.AreaName = ""    // Reset area name for other commands
+			config.AreaID = 0       // Reset area ID for other commands
 			config.PokemonName = "" // Reset Pokemon name for other commands
 		}
-		// Execute the command callback
-		err := command.callback(&config) // Call the command's callback function
-		if err != nil {
+		// Execute the command callback, Ctrl-C cancels the command instead of exiting
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		err := command.callback(ctx, &config) // Call the command's callback function
+		canceled := ctx.Err() != nil
+		stop()
+		if err != nil && canceled {
+			fmt.Println("\nCommand canceled.")
+		} else if err != nil {
 			fmt.Println("Error:", err)
 		}
 	}
@@ -117,7 +282,11 @@ func cleanInput(text string) []string {
 	return words
 }
 
-func commandExit(config *Config) error {
+func commandExit(ctx context.Context, config *Config) error {
+	// Every catch is already saved, this just makes sure nothing is lost on the way out
+	if err := saveProgress(config); err != nil {
+		fmt.Println("Warning:", err)
+	}
 	fmt.Println("Closing the Pokedex... Goodbye!")
 	os.Exit(0)
 	return nil
@@ -126,5 +295,5 @@ func commandExit(config *Config) error {
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(config *Config) error
+	callback    func(ctx context.Context, config *Config) error
 }
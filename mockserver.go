@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/AGX18/pokedex/internal/mockapi"
+)
+
+// runMockServer implements "pokedex mockserver": a local PokeAPI serving embedded fixtures.
+func runMockServer(args []string) {
+	flags := flag.NewFlagSet("mockserver", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	flags.Parse(args)
+
+	fmt.Printf("Serving the mock PokeAPI at http://%s/api/v2\n", *addr)
+	fmt.Printf("Point the Pokedex at it with: pokedex -base-url http://%s/api/v2\n", *addr)
+	handler := logRequests(mockapi.Handler())
+	if err := http.ListenAndServe(*addr, handler); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxPartySize is how many Pokemon travel with the player, as in the games.
+const maxPartySize = 6
+
+// commandParty shows and rearranges the party:
+// "party add <name|id> [nickname]", "party remove <slot|name>", "party swap <slot> <slot>"
+// and "party heal".
+func commandParty(ctx context.Context, config *Config) error {
+	if len(config.Args) == 0 {
+		printParty(config)
+		return nil
+	}
+
+	args := config.Args[1:]
+	switch config.Args[0] {
+	case "add":
+		if len(args) == 0 {
+			fmt.Println("Usage: party add <name|id> [nickname]")
+			return nil
+		}
+		nickname := ""
+		if len(args) > 1 {
+			nickname = args[1]
+		}
+		addToParty(config, args[0], nickname)
+	case "remove":
+		if len(args) == 0 {
+			fmt.Println("Usage: party remove <slot|name>")
+			return nil
+		}
+		slot, ok := findPartySlot(config, args[0])
+		if !ok {
+			return nil
+		}
+		member := caughtByID(config.Caught, config.Party[slot])
+		config.Party = slices.Delete(config.Party, slot, slot+1)
+		fmt.Printf("%s left your party.\n", member.DisplayName())
+	case "swap":
+		if len(args) != 2 {
+			fmt.Println("Usage: party swap <slot> <slot>")
+			return nil
+		}
+		a, okA := findPartySlot(config, args[0])
+		b, okB := findPartySlot(config, args[1])
+		if !okA || !okB {
+			return nil
+		}
+		config.Party[a], config.Party[b] = config.Party[b], config.Party[a]
+		printParty(config)
+	case "heal":
+		for _, id := range config.Party {
+			member := caughtByID(config.Caught, id)
+			member.HP = member.MaxHP
+		}
+		fmt.Println("Your party is fully healed.")
+	default:
+		fmt.Printf("Unknown party command %q.\n", config.Args[0])
+		return nil
+	}
+	return saveProgress(config)
+}
+
+// addToParty adds a caught Pokemon, given by its ID or species name, to the party.
+// With several of a species caught, the first one not in the party joins.
+func addToParty(config *Config, nameOrID, nickname string) {
+	var member *CaughtPokemon
+	if id, err := strconv.Atoi(strings.TrimPrefix(nameOrID, "#")); err == nil {
+		if member = caughtByID(config.Caught, id); member == nil {
+			fmt.Printf("You have not caught a Pokemon #%d.\n", id)
+			return
+		}
+		if slices.Contains(config.Party, id) {
+			fmt.Printf("%s is already in your party.\n", member.DisplayName())
+			return
+		}
+	} else {
+		caught := caughtNamed(config.Caught, nameOrID)
+		if len(caught) == 0 {
+			fmt.Printf("You have not caught %s.\n", nameOrID)
+			return
+		}
+		i := slices.IndexFunc(caught, func(c *CaughtPokemon) bool { return !slices.Contains(config.Party, c.ID) })
+		if i < 0 {
+			fmt.Printf("%s is already in your party.\n", nameOrID)
+			return
+		}
+		member = caught[i]
+	}
+	if len(config.Party) >= maxPartySize {
+		fmt.Println("Your party is full, remove a Pokemon first (party remove <slot>).")
+		return
+	}
+
+	if nickname != "" {
+		member.Nickname = nickname
+	}
+	config.Party = append(config.Party, member.ID)
+	fmt.Printf("%s joined your party.\n", member.DisplayName())
+}
+
+// findPartySlot resolves a 1-based slot number or a name to an index into the party.
+func findPartySlot(config *Config, slotOrName string) (int, bool) {
+	party := config.Party
+	if slot, err := strconv.Atoi(slotOrName); err == nil {
+		if slot < 1 || slot > len(party) {
+			fmt.Printf("There is no slot %d, your party has %d Pokemon.\n", slot, len(party))
+			return 0, false
+		}
+		return slot - 1, true
+	}
+	i := slices.IndexFunc(party, func(id int) bool {
+		member := caughtByID(config.Caught, id)
+		return member.Pokemon.Name == slotOrName || member.Nickname == slotOrName
+	})
+	if i < 0 {
+		fmt.Printf("%s is not in your party.\n", slotOrName)
+		return 0, false
+	}
+	return i, true
+}
+
+func printParty(config *Config) {
+	if len(config.Party) == 0 {
+		fmt.Println("Your party is empty, add a caught Pokemon with party add <name>.")
+		return
+	}
+	fmt.Printf("Your party (%d/%d):\n", len(config.Party), maxPartySize)
+	for i, id := range config.Party {
+		member := caughtByID(config.Caught, id)
+		name := member.Pokemon.Name
+		if member.Nickname != "" {
+			name = fmt.Sprintf("%s (%s)", member.Nickname, member.Pokemon.Name)
+		}
+		fmt.Printf("%d. %s Lv. %d, HP %d/%d\n", i+1, name, member.Level, member.HP, member.MaxHP)
+		if len(member.Moves) > 0 {
+			fmt.Printf("   Moves: %s\n", strings.Join(member.Moves, ", "))
+		}
+	}
+}
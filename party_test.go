@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+func TestCommandParty(t *testing.T) {
+	client := newMockClient(t)
+	config := newTestConfig()
+	for _, name := range []string{"pikachu", "bidoof", "budew", "shinx", "buizel", "magikarp", "wingull", "pikachu"} {
+		pokemon, err := client.GetPokemon(context.Background(), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.Caught = append(config.Caught, newCaughtPokemon(nextCaughtID(config.Caught), pokemon, 12, "", nil))
+	}
+
+	run := func(args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := commandParty(context.Background(), &config); err != nil {
+				t.Errorf("commandParty(%v): %v", args, err)
+			}
+		})
+	}
+	partyNames := func() []string {
+		var names []string
+		for _, id := range config.Party {
+			names = append(names, caughtByID(config.Caught, id).DisplayName())
+		}
+		return names
+	}
+
+	if output := run("add", "gyarados"); !strings.Contains(output, "You have not caught gyarados") {
+		t.Errorf("expected only caught Pokemon to join, got:\n%s", output)
+	}
+	if output := run("add", "#42"); !strings.Contains(output, "You have not caught a Pokemon #42") {
+		t.Errorf("expected an unknown ID to be reported, got:\n%s", output)
+	}
+
+	// Both pikachu can join, the second one by name too
+	run("add", "pikachu", "sparky")
+	run("add", "pikachu")
+	if !slices.Equal(config.Party, []int{1, 8}) || caughtByID(config.Caught, 1).Nickname != "sparky" {
+		t.Errorf("party = %v, expected both pikachu with the first nicknamed", config.Party)
+	}
+	if output := run("add", "pikachu"); !strings.Contains(output, "already in your party") {
+		t.Errorf("expected each pikachu to join only once, got:\n%s", output)
+	}
+	if output := run("add", "8"); !strings.Contains(output, "pikachu is already in your party") {
+		t.Errorf("expected each pikachu to join only once, got:\n%s", output)
+	}
+
+	for _, name := range []string{"bidoof", "budew", "shinx", "buizel"} {
+		run("add", name)
+	}
+	if output := run("add", "wingull"); !strings.Contains(output, "party is full") || len(config.Party) != maxPartySize {
+		t.Errorf("expected the party to hold six Pokemon, got:\n%s", output)
+	}
+
+	run("swap", "1", "6")
+	run("remove", "sparky")
+	run("remove", "2")
+	if names := partyNames(); !slices.Equal(names, []string{"buizel", "bidoof", "budew", "shinx"}) {
+		t.Errorf("party = %v after swapping and removing", names)
+	}
+	if output := run("remove", "7"); !strings.Contains(output, "There is no slot 7") {
+		t.Errorf("expected a missing slot to be reported, got:\n%s", output)
+	}
+
+	output := run()
+	if !strings.Contains(output, "Your party (4/6):\n1. buizel Lv. 12") {
+		t.Errorf("unexpected party listing:\n%s", output)
+	}
+}
+
+func TestSaveLoadParty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	config := newTestConfig()
+	config.SavePath = path
+	config.Caught = []CaughtPokemon{
+		{ID: 1, Pokemon: pokeapi.Pokemon{Name: "pikachu"}, Nickname: "sparky", Level: 12, HP: 30, MaxHP: 30},
+		{ID: 2, Pokemon: pokeapi.Pokemon{Name: "bidoof"}, Level: 8, HP: 20, MaxHP: 27, Moves: []string{"tackle", "growl"}},
+	}
+	config.Seen["pikachu"], config.Seen["bidoof"], config.Seen["starly"] = true, true, true
+	config.Party = []int{2, 1}
+	if err := saveProgress(&config); err != nil {
+		t.Fatalf("saveProgress: %v", err)
+	}
+
+	loaded := newTestConfig()
+	if err := loadProgress(path, &loaded); err != nil {
+		t.Fatalf("loadProgress: %v", err)
+	}
+	if !slices.Equal(loaded.Party, []int{2, 1}) {
+		t.Errorf("loaded party = %v", loaded.Party)
+	}
+	if bidoof := caughtByID(loaded.Caught, 2); bidoof.HP != 20 || !slices.Equal(bidoof.Moves, []string{"tackle", "growl"}) {
+		t.Errorf("loaded party member = %+v", bidoof)
+	}
+	if len(loaded.Seen) != 3 || !loaded.Seen["starly"] {
+		t.Errorf("loaded seen = %v", loaded.Seen)
+	}
+}
+
+// Version 3 saves kept one Pokemon per species and the party by species name
+func TestCommandPokedexSeenAndCaught(t *testing.T) {
+	config := newTestConfig()
+	config.Caught = []CaughtPokemon{
+		{ID: 1, Pokemon: pokeapi.Pokemon{Name: "pikachu"}},
+		{ID: 2, Pokemon: pokeapi.Pokemon{Name: "pikachu"}},
+		{ID: 3, Pokemon: pokeapi.Pokemon{Name: "starly"}},
+	}
+	config.Seen["pikachu"], config.Seen["bidoof"] = true, true
+
+	output := captureOutput(t, func() {
+		if err := commandPokedex(context.Background(), &config); err != nil {
+			t.Errorf("commandPokedex: %v", err)
+		}
+	})
+	expected := "Your Pokedex (seen 3, caught 2):\n- bidoof\n- pikachu (caught 2)\n- starly (caught)\n"
+	if output != expected {
+		t.Errorf("commandPokedex printed:\n%s\nexpected:\n%s", output, expected)
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// The save file keeps the caught Pokemon between sessions.
+// Bump saveFileVersion whenever the layout of saveFile changes.
+//
+// Version 2 added the random seed and state, version 1 files load with a fresh seed.
+// Version 3 added the party and the Pokemon seen.
+// Version 4 keeps every caught Pokemon instead of one per species, see migrateSave.
+// Version 5 added friendship and evolution items, older catches start with defaultFriendship.
+// Version 6 stores caught Pokemon by name, see savedPokemon.
+const saveFileVersion = 6
+
+type saveFile struct {
+	Version  int            `json:"version"`
+	SavedAt  time.Time      `json:"saved_at"`
+	Caught   []savedPokemon `json:"caught"`
+	Balls    map[string]int `json:"balls,omitempty"` // missing in older saves, which keep the starting bag
+	Items    map[string]int `json:"items,omitempty"` // missing in older saves, which keep the starting items
+	Seed     uint64         `json:"seed"`
+	RNGState []byte         `json:"rng_state,omitempty"` // where the sequence started from Seed has got to
+	Seen     []string       `json:"seen,omitempty"`
+	Party    []int          `json:"party,omitempty"` // IDs of caught Pokemon
+}
+
+// savedPokemon is a caught Pokemon in the save file. The PokeAPI data of the Pokemon,
+// hundreds of KB with its moves and sprites, is kept by name only and fetched again
+// by loadCaughtPokemon, the rest is what makes the individual.
+type savedPokemon struct {
+	CaughtPokemon
+	Pokemon pokemonName `json:"pokemon"`
+}
+
+// pokemonName is the name of a saved Pokemon. Versions 4 and 5 stored the whole
+// Pokemon, of which only the name is read.
+type pokemonName string
+
+func (n *pokemonName) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*n = pokemonName(name)
+		return nil
+	}
+	var pokemon struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &pokemon); err != nil {
+		return err
+	}
+	*n = pokemonName(pokemon.Name)
+	return nil
+}
+
+// legacySaveFile is the layout of versions 1 to 3, which kept one Pokemon per species.
+type legacySaveFile struct {
+	Version  int                 `json:"version"`
+	SavedAt  time.Time           `json:"saved_at"`
+	Pokedex  []legacyPokemon     `json:"pokedex"`
+	Balls    map[string]int      `json:"balls,omitempty"`
+	Seed     uint64              `json:"seed"`
+	RNGState []byte              `json:"rng_state,omitempty"`
+	Seen     []string            `json:"seen,omitempty"`
+	Party    []legacyPartyMember `json:"party,omitempty"`
+}
+
+type legacyPokemon struct {
+	CaughtAt time.Time       `json:"caught_at"`
+	Area     string          `json:"area,omitempty"`
+	Level    int             `json:"level,omitempty"`
+	Pokemon  pokeapi.Pokemon `json:"pokemon"`
+}
+
+type legacyPartyMember struct {
+	Name     string   `json:"name"` // species, which was unique among the caught Pokemon
+	Nickname string   `json:"nickname,omitempty"`
+	Level    int      `json:"level"`
+	HP       int      `json:"hp"`
+	MaxHP    int      `json:"max_hp"`
+	Moves    []string `json:"moves"`
+}
+
+// defaultSavePath returns the save file location under the user's config dir,
+// e.g. ~/.config/pokedex/save.json on Linux.
+func defaultSavePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error locating config dir: %w", err)
+	}
+	return filepath.Join(dir, "pokedex", "save.json"), nil
+}
+
+// loadProgress reads the save file at path into config.
+// A missing file is not an error, it just means this is a fresh start.
+// Caught Pokemon are loaded by name only, loadCaughtPokemon fills in the rest.
+func loadProgress(path string, config *Config) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading save file: %w", err)
+	}
+
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return fmt.Errorf("save file %s is corrupted: %w", path, err)
+	}
+	if header.Version < 1 || header.Version > saveFileVersion {
+		return fmt.Errorf("save file %s has unsupported version %d (expected %d)", path, header.Version, saveFileVersion)
+	}
+
+	var save saveFile
+	if header.Version < 4 {
+		save, err = migrateSave(data)
+	} else {
+		err = json.Unmarshal(data, &save)
+	}
+	if err != nil {
+		return fmt.Errorf("save file %s is corrupted: %w", path, err)
+	}
+
+	if save.Balls != nil {
+		config.Balls = save.Balls
+	}
+	if save.Items != nil {
+		config.Items = save.Items
+	}
+	if header.Version >= 2 {
+		seedRNG(config, save.Seed)
+		if len(save.RNGState) > 0 {
+			if err := config.RNGSource.UnmarshalBinary(save.RNGState); err != nil {
+				return fmt.Errorf("save file %s is corrupted: bad random state: %w", path, err)
+			}
+		}
+	}
+	for _, name := range save.Seen {
+		config.Seen[name] = true
+	}
+	caught := make([]CaughtPokemon, 0, len(save.Caught))
+	for _, saved := range save.Caught {
+		saved.CaughtPokemon.Pokemon = pokeapi.Pokemon{Name: string(saved.Pokemon)}
+		caught = append(caught, saved.CaughtPokemon)
+	}
+	ids := make(map[int]bool, len(caught))
+	for _, c := range caught {
+		if c.Pokemon.Name == "" {
+			return fmt.Errorf("save file %s is corrupted: entry without a Pokemon name", path)
+		}
+		if ids[c.ID] {
+			return fmt.Errorf("save file %s is corrupted: two Pokemon with ID %d", path, c.ID)
+		}
+		ids[c.ID] = true
+	}
+	if header.Version < 5 {
+		for i := range caught {
+			caught[i].Friendship = defaultFriendship
+		}
+	}
+	for _, id := range save.Party {
+		if !ids[id] {
+			return fmt.Errorf("save file %s is corrupted: Pokemon #%d is in the party but was never caught", path, id)
+		}
+	}
+	config.Caught = caught
+	config.Party = save.Party
+	return nil
+}
+
+// loadCaughtPokemon fetches the PokeAPI data of every caught Pokemon loaded by
+// loadProgress. The client caches it, and offline it comes from the bundle.
+func loadCaughtPokemon(ctx context.Context, config *Config) error {
+	for i := range config.Caught {
+		c := &config.Caught[i]
+		pokemon, err := config.Client.GetPokemon(ctx, c.Pokemon.Name)
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			return fmt.Errorf("no Pokemon named %s, caught as #%d", c.Pokemon.Name, c.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("error fetching Pokemon data of #%d %s: %w", c.ID, c.Pokemon.Name, describeAPIError(err))
+		}
+		c.Pokemon = pokemon
+	}
+	return nil
+}
+
+// migrateSave converts a save file of versions 1 to 3. Each species caught becomes
+// one CaughtPokemon, numbered in the order of the file, without IVs or ball as
+// those were never recorded. Party members keep their nickname, HP and moves.
+func migrateSave(data []byte) (saveFile, error) {
+	var legacy legacySaveFile
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return saveFile{}, err
+	}
+
+	save := saveFile{
+		Version:  saveFileVersion,
+		SavedAt:  legacy.SavedAt,
+		Caught:   make([]savedPokemon, 0, len(legacy.Pokedex)),
+		Balls:    legacy.Balls,
+		Seed:     legacy.Seed,
+		RNGState: legacy.RNGState,
+		Seen:     legacy.Seen,
+	}
+	for i, entry := range legacy.Pokedex {
+		caught := newCaughtPokemon(i+1, entry.Pokemon, entry.Level, "", nil)
+		caught.CaughtAt = entry.CaughtAt
+		caught.Area = entry.Area
+		save.Caught = append(save.Caught, savedPokemon{CaughtPokemon: caught, Pokemon: pokemonName(entry.Pokemon.Name)})
+	}
+	for _, member := range legacy.Party {
+		i := slices.IndexFunc(save.Caught, func(c savedPokemon) bool { return string(c.Pokemon) == member.Name })
+		if i < 0 {
+			return saveFile{}, fmt.Errorf("%s is in the party but was never caught", member.Name)
+		}
+		caught := &save.Caught[i].CaughtPokemon
+		caught.Nickname = member.Nickname
+		caught.Level = member.Level
+		caught.HP, caught.MaxHP = member.HP, member.MaxHP
+		caught.Moves = member.Moves
+		save.Party = append(save.Party, caught.ID)
+	}
+	return save, nil
+}
+
+// saveProgress writes the caught Pokemon to config.SavePath.
+// Nothing is written when no save path is configured.
+func saveProgress(config *Config) error {
+	if config.SavePath == "" {
+		return nil
+	}
+
+	save := saveFile{
+		Version: saveFileVersion,
+		SavedAt: time.Now(),
+		Caught:  make([]savedPokemon, 0, len(config.Caught)),
+		Balls:   config.Balls,
+		Items:   config.Items,
+		Seed:    config.Seed,
+		Party:   config.Party,
+	}
+	for _, caught := range config.Caught {
+		save.Caught = append(save.Caught, savedPokemon{CaughtPokemon: caught, Pokemon: pokemonName(caught.Pokemon.Name)})
+	}
+	for name := range config.Seen {
+		save.Seen = append(save.Seen, name)
+	}
+	slices.Sort(save.Seen)
+	if config.RNGSource != nil {
+		state, err := config.RNGSource.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("error encoding random state: %w", err)
+		}
+		save.RNGState = state
+	}
+
+	data, err := json.Marshal(save)
+	if err != nil {
+		return fmt.Errorf("error encoding save file: %w", err)
+	}
+	if err := writeFileAtomic(config.SavePath, data); err != nil {
+		return fmt.Errorf("error writing save file: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it into place,
+// so a crash mid-write leaves either the old file or the new one, never half of each.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once the rename succeeded
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
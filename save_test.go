@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+func newTestConfig() Config {
+	config := Config{
+		Limit: 20,
+		Seen:  make(map[string]bool),
+		Balls: startingBalls(),
+		Items: startingItems(),
+	}
+	seedRNG(&config, 1)
+	return config
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex", "save.json")
+	caughtAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	config := newTestConfig()
+	config.SavePath = path
+	config.Caught = []CaughtPokemon{{
+		ID:       1,
+		Pokemon:  pokeapi.Pokemon{Name: "pikachu", BaseExperience: 112, Height: 4},
+		CaughtAt: caughtAt,
+		Area:     "viridian-forest-area",
+		Level:    7,
+		IVs:      map[string]int{"hp": 31, "speed": 4},
+		Ball:     "great-ball",
+	}}
+	config.Balls["great-ball"] = 3
+	config.Items["thunder-stone"] = 0
+
+	if err := saveProgress(&config); err != nil {
+		t.Fatalf("saveProgress: %v", err)
+	}
+
+	// Only the name of the Pokemon is saved, its data comes from PokeAPI
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"pokemon":"pikachu"`) || strings.Contains(string(data), "base_experience") {
+		t.Errorf("expected pikachu to be saved by name, got:\n%s", data)
+	}
+
+	loaded := newTestConfig()
+	loaded.Client = newMockClient(t)
+	if err := loadProgress(path, &loaded); err != nil {
+		t.Fatalf("loadProgress: %v", err)
+	}
+	if err := loadCaughtPokemon(context.Background(), &loaded); err != nil {
+		t.Fatalf("loadCaughtPokemon: %v", err)
+	}
+	if len(loaded.Caught) != 1 {
+		t.Fatalf("expected pikachu in loaded pokedex")
+	}
+	caught := loaded.Caught[0]
+	if caught.Pokemon.BaseExperience != 112 || caught.Pokemon.Height != 4 || len(caught.Pokemon.Moves) == 0 {
+		t.Errorf("loaded pokemon = %+v, expected the PokeAPI data of pikachu", caught.Pokemon)
+	}
+	if loaded.Balls["great-ball"] != 3 || loaded.Balls["poke-ball"] != 20 {
+		t.Errorf("loaded balls = %v", loaded.Balls)
+	}
+	if loaded.Items["thunder-stone"] != 0 || loaded.Items["water-stone"] != 1 {
+		t.Errorf("loaded items = %v", loaded.Items)
+	}
+	if !caught.CaughtAt.Equal(caughtAt) || caught.Area != "viridian-forest-area" || caught.Level != 7 || caught.Ball != "great-ball" || caught.IVs["hp"] != 31 {
+		t.Errorf("loaded catch info = %+v", caught)
+	}
+
+	// The temp file used for the atomic write must not be left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the save file, found %d entries", len(entries))
+	}
+}
+
+func TestLoadMissingSaveFile(t *testing.T) {
+	config := newTestConfig()
+	err := loadProgress(filepath.Join(t.TempDir(), "missing.json"), &config)
+	if err != nil {
+		t.Fatalf("expected no error for a missing save file, got %v", err)
+	}
+	if len(config.Caught) != 0 {
+		t.Errorf("expected an empty pokedex")
+	}
+}
+
+func TestLoadRejectsBadSaveFiles(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{name: "truncated", content: `{"version":1,"pokedex":[{"pok`, errText: "corrupted"},
+		{name: "future version", content: `{"version":99,"pokedex":[]}`, errText: "unsupported version"},
+		{name: "missing version", content: `{"pokedex":[]}`, errText: "unsupported version"},
+		{name: "nameless entry", content: `{"version":1,"pokedex":[{"pokemon":{}}]}`, errText: "corrupted"},
+		{name: "bad random state", content: `{"version":2,"pokedex":[],"seed":1,"rng_state":"AAAA"}`, errText: "corrupted"},
+		{name: "duplicate ID", content: `{"version":4,"caught":[{"id":1,"pokemon":{"name":"bidoof"}},{"id":1,"pokemon":{"name":"pikachu"}}]}`, errText: "corrupted"},
+		{name: "party member not caught", content: `{"version":4,"caught":[],"party":[3]}`, errText: "corrupted"},
+		{name: "version 3 party member not caught", content: `{"version":3,"pokedex":[],"party":[{"name":"mew","level":5}]}`, errText: "corrupted"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "save.json")
+			if err := os.WriteFile(path, []byte(c.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			config := newTestConfig()
+			err := loadProgress(path, &config)
+			if err == nil || !strings.Contains(err.Error(), c.errText) {
+				t.Errorf("loadProgress() error = %v, expected it to mention %q", err, c.errText)
+			}
+		})
+	}
+}
+
+func TestSaveLoadRandomState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	config := newTestConfig()
+	config.SavePath = path
+	seedRNG(&config, 42)
+	config.RNG.IntN(100) // the saved state is past the seed
+	if err := saveProgress(&config); err != nil {
+		t.Fatalf("saveProgress: %v", err)
+	}
+	expected := config.RNG.Uint64()
+
+	loaded := newTestConfig()
+	if err := loadProgress(path, &loaded); err != nil {
+		t.Fatalf("loadProgress: %v", err)
+	}
+	if loaded.Seed != 42 {
+		t.Errorf("loaded seed = %d, expected 42", loaded.Seed)
+	}
+	if got := loaded.RNG.Uint64(); got != expected {
+		t.Errorf("the loaded random sequence does not carry on where it was saved: %d != %d", got, expected)
+	}
+}
+
+func TestLoadVersion1SaveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	content := `{"version":1,"pokedex":[{"caught_at":"2024-05-01T12:00:00Z","pokemon":{"name":"pikachu"}}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := newTestConfig()
+	seedRNG(&config, 7)
+	if err := loadProgress(path, &config); err != nil {
+		t.Fatalf("loadProgress: %v", err)
+	}
+	if len(caughtNamed(config.Caught, "pikachu")) != 1 {
+		t.Errorf("expected pikachu in loaded pokedex")
+	}
+	// Files from before seeds keep the seed of the session
+	if config.Seed != 7 || config.Balls["poke-ball"] != 20 {
+		t.Errorf("seed = %d, balls = %v, expected the session defaults", config.Seed, config.Balls)
+	}
+}
+
+func TestLoadVersion3SaveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	content := `{"version":3,"seed":9,
+		"pokedex":[
+			{"caught_at":"2024-05-01T12:00:00Z","area":"eterna-forest-area","level":4,"pokemon":{"name":"bidoof"}},
+			{"caught_at":"2024-05-02T12:00:00Z","level":9,"pokemon":{"name":"pikachu"}},
+			{"caught_at":"2024-05-03T12:00:00Z","level":3,"pokemon":{"name":"starly"}}],
+		"seen":["bidoof","pikachu","starly","zubat"],
+		"party":[
+			{"name":"pikachu","nickname":"Sparky","level":12,"hp":20,"max_hp":33,"moves":["thunder-shock","growl","quick-attack"]},
+			{"name":"bidoof","level":4,"hp":19,"max_hp":19,"moves":["tackle"]}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := newTestConfig()
+	if err := loadProgress(path, &config); err != nil {
+		t.Fatalf("loadProgress: %v", err)
+	}
+	// Catches are numbered in the order of the file, the party keeps its order
+	names := make([]string, len(config.Caught))
+	for i, c := range config.Caught {
+		names[i] = fmt.Sprintf("#%d %s", c.ID, c.Pokemon.Name)
+	}
+	if fmt.Sprint(names) != "[#1 bidoof #2 pikachu #3 starly]" {
+		t.Errorf("caught = %v", names)
+	}
+	if fmt.Sprint(config.Party) != "[2 1]" {
+		t.Errorf("party = %v, expected [2 1]", config.Party)
+	}
+
+	pikachu := caughtByID(config.Caught, 2)
+	if pikachu.Nickname != "Sparky" || pikachu.Level != 12 || pikachu.HP != 20 || pikachu.MaxHP != 33 {
+		t.Errorf("party member = %+v, expected its nickname, level and HP", pikachu)
+	}
+	if fmt.Sprint(pikachu.Moves) != "[thunder-shock growl quick-attack]" {
+		t.Errorf("moves = %v", pikachu.Moves)
+	}
+	bidoof := caughtByID(config.Caught, 1)
+	if bidoof.Area != "eterna-forest-area" || fmt.Sprint(bidoof.Moves) != "[tackle]" {
+		t.Errorf("party member = %+v", bidoof)
+	}
+	if starly := caughtByID(config.Caught, 3); starly.Level != 3 || starly.Friendship != defaultFriendship || starly.IVs != nil {
+		t.Errorf("caught = %+v, expected level 3 with default friendship and no IVs", starly)
+	}
+	if config.Seed != 9 || !config.Seen["zubat"] {
+		t.Errorf("seed = %d, seen = %v", config.Seed, config.Seen)
+	}
+}
+
+func TestLoadVersion5SaveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	content := `{"version":5,"caught":[
+		{"id":1,"pokemon":{"name":"pikachu","base_experience":112,"moves":[]},"level":12,"friendship":90,"hp":30,"max_hp":33}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := newTestConfig()
+	if err := loadProgress(path, &config); err != nil {
+		t.Fatalf("loadProgress: %v", err)
+	}
+	// The whole Pokemon stored by version 5 is only read for its name
+	if c := config.Caught[0]; c.Pokemon.Name != "pikachu" || c.Level != 12 || c.Friendship != 90 || c.HP != 30 {
+		t.Errorf("loaded %+v", c)
+	}
+}
+
+func TestLoadCaughtPokemonUnknown(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	config.Caught = []CaughtPokemon{{ID: 3, Pokemon: pokeapi.Pokemon{Name: "notapokemon"}}}
+
+	err := loadCaughtPokemon(context.Background(), &config)
+	if err == nil || !strings.Contains(err.Error(), "#3") {
+		t.Errorf("loadCaughtPokemon() error = %v, expected it to name #3", err)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+)
+
+// seedRNG resets the random source behind every game mechanic,
+// so the same seed always plays out the same encounters and catches.
+func seedRNG(config *Config, seed uint64) {
+	config.Seed = seed
+	config.RNGSource = rand.NewPCG(seed, seed)
+	config.RNG = rand.New(config.RNGSource)
+}
+
+// commandSeed shows the seed of the session, or restarts the random sequence from a new one.
+func commandSeed(ctx context.Context, config *Config) error {
+	if len(config.Args) == 0 {
+		fmt.Printf("Seed: %d\n", config.Seed)
+		fmt.Println("Use seed <number> to replay a session, or start the Pokedex with -seed <number>.")
+		return nil
+	}
+	seed, err := strconv.ParseUint(config.Args[0], 10, 64)
+	if err != nil {
+		fmt.Printf("%q is not a valid seed, use a number like 42.\n", config.Args[0])
+		return nil
+	}
+	seedRNG(config, seed)
+	fmt.Printf("Seeded with %d.\n", seed)
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSameSeedSameEncounters(t *testing.T) {
+	client := newMockClient(t)
+	play := func(seed uint64) []WildPokemon {
+		config := newTestConfig()
+		config.Client = client
+		config.CurrentArea = "great-marsh-area-4"
+		seedRNG(&config, seed)
+
+		var met []WildPokemon
+		captureOutput(t, func() {
+			for range 10 {
+				if err := commandEncounter(context.Background(), &config); err != nil {
+					t.Fatalf("commandEncounter: %v", err)
+				}
+				met = append(met, *config.Wild)
+			}
+		})
+		return met
+	}
+
+	first, second := play(42), play(42)
+	if !slices.Equal(first, second) {
+		t.Errorf("the same seed met different Pokemon:\n%v\n%v", first, second)
+	}
+	if slices.Equal(first, play(43)) {
+		t.Errorf("another seed met the very same Pokemon, the seed is not used")
+	}
+}
+
+func TestEncounterSavesProgress(t *testing.T) {
+	client := newMockClient(t)
+	config := newTestConfig()
+	config.Client = client
+	config.CurrentArea = "great-marsh-area-4"
+	config.SavePath = filepath.Join(t.TempDir(), "save.json")
+	encounter := func(config *Config) WildPokemon {
+		captureOutput(t, func() {
+			if err := commandEncounter(context.Background(), config); err != nil {
+				t.Fatalf("commandEncounter: %v", err)
+			}
+		})
+		return *config.Wild
+	}
+	first := encounter(&config)
+
+	// A session restarted from the save meets what the running one meets next
+	resumed := newTestConfig()
+	if err := loadProgress(config.SavePath, &resumed); err != nil {
+		t.Fatal(err)
+	}
+	resumed.Client = client
+	resumed.CurrentArea = config.CurrentArea
+	if !resumed.Seen[first.Name] {
+		t.Errorf("expected %s to be saved as seen", first.Name)
+	}
+	if next, resumedNext := encounter(&config), encounter(&resumed); next != resumedNext {
+		t.Errorf("the resumed session met %+v instead of %+v", resumedNext, next)
+	}
+}
+
+func TestCommandSeed(t *testing.T) {
+	config := newTestConfig()
+	run := func(args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := commandSeed(context.Background(), &config); err != nil {
+				t.Errorf("commandSeed(%v): %v", args, err)
+			}
+		})
+	}
+
+	run("1234")
+	expected := config.RNG.Uint64()
+	run("1234")
+	if config.Seed != 1234 || config.RNG.Uint64() != expected {
+		t.Errorf("seed 1234 does not restart the same sequence")
+	}
+	if output := run(); !strings.Contains(output, "Seed: 1234") {
+		t.Errorf("expected the seed to be shown, got:\n%s", output)
+	}
+	if output := run("lucky"); !strings.Contains(output, "not a valid seed") || config.Seed != 1234 {
+		t.Errorf("expected an invalid seed to be rejected, got:\n%s", output)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// defaultBundleDir is where sync stores the offline copy of PokeAPI.
+func defaultBundleDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error locating config dir: %w", err)
+	}
+	return filepath.Join(dir, "pokedex", "bundle"), nil
+}
+
+// commandSync downloads resources into the offline bundle:
+// "sync" alone fetches the list of location areas used by map,
+// "sync <area>..." also fetches those areas or locations and every Pokemon found in them,
+// "sync pokemon <name>..." and "sync version <name>..." fetch single Pokemon and game versions.
+// Pokemon come with their species, growth rate and evolution chain, which catching,
+// leveling and evolving need.
+func commandSync(ctx context.Context, config *Config) error {
+	if config.Offline {
+		fmt.Println("sync needs a network connection, restart the Pokedex without -offline.")
+		return nil
+	}
+	if config.BundleDir == "" {
+		fmt.Println("No bundle directory is configured.")
+		return nil
+	}
+	if strings.HasSuffix(strings.ToLower(config.BundleDir), ".zip") {
+		fmt.Printf("sync cannot write into the archive %s, restart the Pokedex with -bundle set to a directory.\n", config.BundleDir)
+		return nil
+	}
+
+	if len(config.Args) > 0 && (config.Args[0] == "pokemon" || config.Args[0] == "version") {
+		synced := make(map[string]bool)
+		for _, name := range config.Args[1:] {
+			var err error
+			if config.Args[0] == "pokemon" {
+				err = syncPokemon(ctx, config, name, synced)
+			} else {
+				_, err = syncItem(ctx, config, config.Args[0], name)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Synced %s\n", name)
+		}
+		return nil
+	}
+
+	fmt.Println("Syncing the list of location areas...")
+	if err := syncLocationAreaIndex(ctx, config); err != nil {
+		return err
+	}
+	synced := make(map[string]bool)
+	for _, area := range config.Args {
+		if err := syncArea(ctx, config, area, synced); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Bundle saved to %s\n", config.BundleDir)
+	return nil
+}
+
+func syncLocationAreaIndex(ctx context.Context, config *Config) error {
+	// Ask for a single item first to learn how many there are
+	first, err := config.Client.ListLocationAreas(ctx, 0, 1)
+	if err != nil {
+		return fmt.Errorf("error fetching location areas: %w", describeAPIError(err))
+	}
+	all, err := config.Client.ListLocationAreas(ctx, 0, first.Count)
+	if err != nil {
+		return fmt.Errorf("error fetching location areas: %w", describeAPIError(err))
+	}
+
+	items := make([]pokeapi.BundleItem, 0, len(all.Results))
+	for _, area := range all.Results {
+		items = append(items, pokeapi.BundleItem{Name: area.Name, URL: area.URL})
+	}
+	if err := pokeapi.WriteBundleIndex(config.BundleDir, "location-area", items); err != nil {
+		return fmt.Errorf("error writing bundle: %w", err)
+	}
+	return nil
+}
+
+func syncArea(ctx context.Context, config *Config, name string, synced map[string]bool) error {
+	data, err := syncItem(ctx, config, "location-area", name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return syncLocation(ctx, config, name, synced)
+	}
+	if err != nil {
+		return err
+	}
+
+	var area pokeapi.LocationArea
+	if err := json.Unmarshal(data, &area); err != nil {
+		return fmt.Errorf("error decoding area data: %w", err)
+	}
+	fmt.Printf("Syncing %s and %d Pokemon...\n", area.Name, len(area.PokemonEncounters))
+	for _, encounter := range area.PokemonEncounters {
+		if err := syncPokemon(ctx, config, encounter.Pokemon.Name, synced); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncPokemon fetches a Pokemon and its species with everything syncSpecies brings along.
+// synced holds the species already fetched by this sync.
+func syncPokemon(ctx context.Context, config *Config, name string, synced map[string]bool) error {
+	data, err := syncItem(ctx, config, "pokemon", name)
+	if err != nil {
+		return err
+	}
+	var pokemon pokeapi.Pokemon
+	if err := json.Unmarshal(data, &pokemon); err != nil {
+		return fmt.Errorf("error decoding Pokemon data: %w", err)
+	}
+	return syncSpecies(ctx, config, pokemon.Species.Name, synced)
+}
+
+// syncSpecies fetches a species, its growth rate and its evolution chain, then every
+// species of the chain and the Pokemon they evolve into.
+func syncSpecies(ctx context.Context, config *Config, name string, synced map[string]bool) error {
+	if synced[name] {
+		return nil
+	}
+	synced[name] = true
+
+	data, err := syncItem(ctx, config, "pokemon-species", name)
+	if err != nil {
+		return err
+	}
+	var species pokeapi.PokemonSpecies
+	if err := json.Unmarshal(data, &species); err != nil {
+		return fmt.Errorf("error decoding species data: %w", err)
+	}
+	if _, err := syncItem(ctx, config, "growth-rate", species.GrowthRate.Name); err != nil {
+		return err
+	}
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			if _, err := syncItem(ctx, config, "pokemon", variety.Pokemon.Name); err != nil {
+				return err
+			}
+		}
+	}
+	if species.EvolutionChain.URL == "" {
+		return nil
+	}
+
+	data, err = syncItem(ctx, config, "evolution-chain", evolutionChainID(species))
+	if err != nil {
+		return err
+	}
+	var chain pokeapi.EvolutionChain
+	if err := json.Unmarshal(data, &chain); err != nil {
+		return fmt.Errorf("error decoding evolution chain: %w", err)
+	}
+	stages := []pokeapi.EvolutionStage{chain.Chain}
+	for len(stages) > 0 {
+		stage := stages[0]
+		if err := syncSpecies(ctx, config, stage.Species.Name, synced); err != nil {
+			return err
+		}
+		stages = append(stages[1:], stage.EvolvesTo...)
+	}
+	return nil
+}
+
+// syncLocation fetches a location and every one of its areas, so explore works offline by location name.
+func syncLocation(ctx context.Context, config *Config, name string, synced map[string]bool) error {
+	data, err := syncItem(ctx, config, "location", name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("no location or location area named %s\n", name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var location pokeapi.Location
+	if err := json.Unmarshal(data, &location); err != nil {
+		return fmt.Errorf("error decoding location data: %w", err)
+	}
+	for _, area := range location.Areas {
+		if err := syncArea(ctx, config, area.Name, synced); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncItem fetches a single resource item and writes it to the bundle.
+func syncItem(ctx context.Context, config *Config, resource, nameOrID string) (json.RawMessage, error) {
+	var data json.RawMessage
+	err := pokeapi.GetWithCache(ctx, config.Client, config.Client.ResourceURL(resource, nameOrID), &data)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s %s: %w", resource, nameOrID, describeAPIError(err))
+	}
+
+	var item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("error decoding %s %s: %w", resource, nameOrID, err)
+	}
+	if err := pokeapi.WriteBundleItem(config.BundleDir, resource, item.ID, item.Name, data); err != nil {
+		return nil, fmt.Errorf("error writing bundle: %w", err)
+	}
+	return data, nil
+}
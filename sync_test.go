@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// syncOffline syncs args from the mock API into a new bundle and returns a client reading it.
+func syncOffline(t *testing.T, args ...string) *pokeapi.Client {
+	t.Helper()
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	config.BundleDir = t.TempDir()
+	config.Args = args
+	captureOutput(t, func() {
+		if err := commandSync(context.Background(), &config); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	transport, closer, err := pokeapi.OpenBundle(config.BundleDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { closer.Close() })
+	return pokeapi.NewClient(pokeapi.WithHTTPClient(&http.Client{Transport: transport}))
+}
+
+func TestSyncAreaCanCatchOffline(t *testing.T) {
+	config := newTestConfig()
+	config.Client = syncOffline(t, "canalave-city-area")
+	run := func(command func(context.Context, *Config) error, args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := command(context.Background(), &config); err != nil {
+				t.Errorf("%v: %v", args, err)
+			}
+		})
+	}
+
+	config.AreaName = "canalave-city-area"
+	run(commandExplore, "canalave-city-area")
+	run(commandEncounter, "--method", "old-rod") // only magikarp bites
+	output := run(commandCatch, "--ball", "master")
+	if len(config.Caught) != 1 {
+		t.Fatalf("expected a catch from the synced bundle, got:\n%s", output)
+	}
+	// Evolving needs the evolution chain
+	caught := &config.Caught[0]
+	caught.Level = maxLevel
+	output = run(commandEvolve, "1")
+	if caught.Pokemon.Name != "gyarados" {
+		t.Errorf("expected magikarp to evolve from the synced bundle, got:\n%s", output)
+	}
+}
+
+func TestSyncPokemonBringsEvolutions(t *testing.T) {
+	config := newTestConfig()
+	config.Client = syncOffline(t, "pokemon", "pikachu")
+	pikachu, err := config.Client.GetPokemon(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Caught = []CaughtPokemon{newCaughtPokemon(1, pikachu, 10, "", nil)}
+
+	// raichu is only synced as the evolution of pikachu
+	config.Args = []string{"1", "--item", "thunder-stone"}
+	output := captureOutput(t, func() {
+		if err := commandEvolve(context.Background(), &config); err != nil {
+			t.Error(err)
+		}
+	})
+	if config.Caught[0].Pokemon.Name != "raichu" {
+		t.Errorf("expected pikachu to evolve from the synced bundle, got:\n%s", output)
+	}
+}
+
+func TestSyncRejectsArchive(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	config.BundleDir = filepath.Join(t.TempDir(), "bundle.zip")
+	config.Args = []string{"pokemon", "pikachu"}
+
+	output := captureOutput(t, func() {
+		if err := commandSync(context.Background(), &config); err != nil {
+			t.Errorf("commandSync: %v", err)
+		}
+	})
+	if !strings.Contains(output, "cannot write into the archive") {
+		t.Errorf("expected sync to refuse an archive, got:\n%s", output)
+	}
+}
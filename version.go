@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/AGX18/pokedex/internal/pokeapi"
+)
+
+// commandVersion shows or changes the game the Pokedex follows:
+// "version set <name>" scopes explore, inspect and catch to that game, "version clear" covers every game again.
+func commandVersion(ctx context.Context, config *Config) error {
+	if len(config.Args) == 0 {
+		if config.Version == "" {
+			fmt.Println("No game version is set, the Pokedex covers every game.")
+		} else {
+			fmt.Printf("Game version: %s (%s)\n", config.Version, config.VersionGroup)
+		}
+		fmt.Println("Usage: version set <name> | version clear")
+		return nil
+	}
+
+	switch config.Args[0] {
+	case "set":
+		if len(config.Args) < 2 {
+			fmt.Println("Please provide a game version, e.g. version set firered.")
+			return nil
+		}
+		version, err := config.Client.GetVersion(ctx, config.Args[1])
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			fmt.Printf("no game version named %s\n", config.Args[1])
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error fetching version data: %w", describeAPIError(err))
+		}
+		config.Version = version.Name
+		config.VersionGroup = version.VersionGroup.Name
+		fmt.Printf("The Pokedex now follows Pokemon %s.\n", version.Name)
+	case "clear":
+		config.Version, config.VersionGroup = "", ""
+		fmt.Println("The Pokedex now covers every game.")
+	default:
+		fmt.Printf("Unknown version command %q.\n", config.Args[0])
+	}
+	return nil
+}
+
+// inVersion reports whether a Pokemon can be found in a game.
+// PokeAPI has no game indices for recent games, so a Pokemon without any is let through.
+func inVersion(pokemon pokeapi.Pokemon, version string) bool {
+	if version == "" || len(pokemon.GameIndices) == 0 {
+		return true
+	}
+	for _, index := range pokemon.GameIndices {
+		if index.Version.Name == version {
+			return true
+		}
+	}
+	return false
+}
+
+// spriteURL picks the sprite drawn for a version group, or the default sprite
+// when the group has none or no group is given.
+func spriteURL(pokemon pokeapi.Pokemon, versionGroup string) string {
+	versions := pokemon.Sprites.Versions
+	var url string
+	switch versionGroup {
+	case "red-blue":
+		url = versions.GenerationI.RedBlue.FrontDefault
+	case "yellow":
+		url = versions.GenerationI.Yellow.FrontDefault
+	case "gold-silver":
+		url = versions.GenerationIi.Gold.FrontDefault
+	case "crystal":
+		url = versions.GenerationIi.Crystal.FrontDefault
+	case "ruby-sapphire":
+		url = versions.GenerationIii.RubySapphire.FrontDefault
+	case "emerald":
+		url = versions.GenerationIii.Emerald.FrontDefault
+	case "firered-leafgreen":
+		url = versions.GenerationIii.FireredLeafgreen.FrontDefault
+	case "diamond-pearl":
+		url = versions.GenerationIv.DiamondPearl.FrontDefault
+	case "platinum":
+		url = versions.GenerationIv.Platinum.FrontDefault
+	case "heartgold-soulsilver":
+		url = versions.GenerationIv.HeartgoldSoulsilver.FrontDefault
+	case "black-white", "black-2-white-2":
+		url = versions.GenerationV.BlackWhite.FrontDefault
+	case "x-y":
+		url = versions.GenerationVi.XY.FrontDefault
+	case "omega-ruby-alpha-sapphire":
+		url = versions.GenerationVi.OmegarubyAlphasapphire.FrontDefault
+	case "ultra-sun-ultra-moon":
+		url = versions.GenerationVii.UltraSunUltraMoon.FrontDefault
+	}
+	if url == "" {
+		url = pokemon.Sprites.FrontDefault
+	}
+	return url
+}
+
+type levelUpMove struct {
+	name  string
+	level int
+}
+
+// levelUpMoves lists the moves a Pokemon learns by leveling up in a version group, by level.
+func levelUpMoves(pokemon pokeapi.Pokemon, versionGroup string) []levelUpMove {
+	var moves []levelUpMove
+	for _, move := range pokemon.Moves {
+		for _, details := range move.VersionGroupDetails {
+			if details.VersionGroup.Name == versionGroup && details.MoveLearnMethod.Name == "level-up" {
+				moves = append(moves, levelUpMove{name: move.Move.Name, level: details.LevelLearnedAt})
+				break
+			}
+		}
+	}
+	slices.SortStableFunc(moves, func(a, b levelUpMove) int { return a.level - b.level })
+	return moves
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCommandVersion(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+
+	run := func(args ...string) string {
+		config.Args = args
+		return captureOutput(t, func() {
+			if err := commandVersion(context.Background(), &config); err != nil {
+				t.Errorf("commandVersion(%v): %v", args, err)
+			}
+		})
+	}
+
+	run("set", "pearl")
+	if config.Version != "pearl" || config.VersionGroup != "diamond-pearl" {
+		t.Errorf("after version set pearl: version=%q group=%q", config.Version, config.VersionGroup)
+	}
+	if output := run("set", "pokemon-snap"); !strings.Contains(output, "no game version named pokemon-snap") {
+		t.Errorf("expected an unknown version to be reported, got:\n%s", output)
+	}
+	if config.Version != "pearl" {
+		t.Errorf("an unknown version must not replace the current one, got %q", config.Version)
+	}
+	run("clear")
+	if config.Version != "" || config.VersionGroup != "" {
+		t.Errorf("after version clear: version=%q group=%q", config.Version, config.VersionGroup)
+	}
+}
+
+func TestVersionScopedPokemonData(t *testing.T) {
+	client := newMockClient(t)
+	bidoof, err := client.GetPokemon(context.Background(), "bidoof")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sprite := spriteURL(bidoof, "platinum"); !strings.Contains(sprite, "generation-iv/platinum/399.png") {
+		t.Errorf("spriteURL(platinum) = %q", sprite)
+	}
+	// Bidoof was not drawn for Red and Blue
+	if sprite := spriteURL(bidoof, "red-blue"); sprite != bidoof.Sprites.FrontDefault {
+		t.Errorf("spriteURL(red-blue) = %q, expected the default sprite", sprite)
+	}
+
+	moves := levelUpMoves(bidoof, "diamond-pearl")
+	if len(moves) != 6 || moves[0].name != "tackle" || moves[5].name != "hyper-fang" || moves[5].level != 21 {
+		t.Errorf("levelUpMoves(diamond-pearl) = %+v", moves)
+	}
+	if moves := levelUpMoves(bidoof, "red-blue"); len(moves) != 0 {
+		t.Errorf("levelUpMoves(red-blue) = %+v, expected none", moves)
+	}
+
+	if !inVersion(bidoof, "pearl") || inVersion(bidoof, "red") || !inVersion(bidoof, "") {
+		t.Errorf("inVersion does not match the game indices of bidoof")
+	}
+}
+
+func TestCommandCatchOutsideVersion(t *testing.T) {
+	config := newTestConfig()
+	config.Client = newMockClient(t)
+	config.Version = "red"
+	config.PokemonName = "bidoof"
+	config.Wild = &WildPokemon{Name: "bidoof", Level: 5}
+
+	output := captureOutput(t, func() {
+		if err := commandCatch(context.Background(), &config); err != nil {
+			t.Errorf("commandCatch: %v", err)
+		}
+	})
+	if !strings.Contains(output, "bidoof cannot be found in Pokemon red") || len(config.Caught) != 0 {
+		t.Errorf("expected bidoof to be out of reach in red, got:\n%s", output)
+	}
+}